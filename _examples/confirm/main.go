@@ -7,17 +7,17 @@ import (
 )
 
 func main() {
-	prompt := promptui.Prompt{
+	confirm := promptui.Confirm{
 		Label:     "Delete Resource",
-		IsConfirm: true,
+		SingleKey: true,
 	}
 
-	result, err := prompt.Run()
+	result, err := confirm.Run()
 
 	if err != nil {
-		fmt.Printf("Prompt failed %v\n", err)
+		fmt.Printf("Confirm failed %v\n", err)
 		return
 	}
 
-	fmt.Printf("You choose %q\n", result)
+	fmt.Printf("You choose %t\n", result)
 }
@@ -8,25 +8,29 @@ import (
 )
 
 func main() {
-	validate := func(input string) error {
+	validate := func(input []byte) error {
 		if len(input) < 6 {
 			return errors.New("Password must have more than 6 characters")
 		}
 		return nil
 	}
 
-	prompt := promptui.Prompt{
-		Label:    "Password",
-		Validate: validate,
-		Mask:     '*',
+	prompt := promptui.Password{
+		Label:        "Password",
+		ConfirmLabel: "Repeat password",
+		Validate:     validate,
 	}
 
-	result, err := prompt.Run()
+	result, err := prompt.RunBytes()
 
 	if err != nil {
 		fmt.Printf("Prompt failed %v\n", err)
 		return
 	}
 
-	fmt.Printf("Your password is %q\n", result)
+	fmt.Printf("Your password has %d bytes\n", len(result))
+
+	for i := range result {
+		result[i] = 0
+	}
 }
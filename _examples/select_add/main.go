@@ -7,29 +7,18 @@ import (
 )
 
 func main() {
-	items := []string{"Vim", "Emacs", "Sublime", "VSCode", "Atom"}
-	index := -1
-	var result string
-	var err error
-
-	for index < 0 {
-		prompt := promptui.SelectWithAdd{
-			Label:    "What's your text editor",
-			Items:    items,
-			AddLabel: "Other",
-		}
-
-		index, result, err = prompt.Run()
-
-		if index == -1 {
-			items = append(items, result)
-		}
+	prompt := promptui.SelectWithAdd{
+		Label:    "What's your text editor",
+		Items:    []string{"Vim", "Emacs", "Sublime", "VSCode", "Atom"},
+		AddLabel: "Other",
 	}
 
+	_, result, items, err := prompt.Run()
+
 	if err != nil {
 		fmt.Printf("Prompt failed %v\n", err)
 		return
 	}
 
-	fmt.Printf("You choose %s\n", result)
+	fmt.Printf("You choose %s among %v\n", result, items)
 }
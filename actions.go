@@ -0,0 +1,112 @@
+package promptui
+
+import (
+	"reflect"
+
+	"github.com/lemotw/promptui/list"
+)
+
+// Actioner can be implemented by the items of a select to declare the secondary actions offered by their
+// action menu, like "open", "copy" or "delete".
+type Actioner interface {
+	Actions() []string
+}
+
+// ActionsFunc is a function that returns the secondary actions of the given item. It is used for items that
+// don't implement the Actioner interface. Items without actions have no action menu.
+type ActionsFunc func(item interface{}) []string
+
+// SelectResult is the result of a select whose items have secondary actions.
+type SelectResult struct {
+	// Index is the index of the selected item, as returned by Run.
+	Index int
+	// Item is the selected item.
+	Item interface{}
+	// Action is the action chosen from the action menu of the item, or an empty string when the item was
+	// selected directly.
+	Action string
+}
+
+// RunWithAction executes the select list like Run. The item can also be selected by choosing one of its
+// actions from its action menu, in which case the action is part of the result.
+func (s *Select) RunWithAction() (SelectResult, error) {
+	index, item, err := s.Run()
+	if err != nil {
+		return SelectResult{}, err
+	}
+
+	return SelectResult{Index: index, Item: item, Action: s.action}, nil
+}
+
+// actions returns the secondary actions of the given item.
+func (s *Select) actions(item interface{}) []string {
+	if a, ok := item.(Actioner); ok {
+		return a.Actions()
+	}
+
+	if s.Actions != nil {
+		return s.Actions(item)
+	}
+
+	return nil
+}
+
+// hasActions returns whether any item of the select has an action menu.
+func (s *Select) hasActions() bool {
+	if s.Actions != nil {
+		return true
+	}
+
+	if s.Items == nil || reflect.TypeOf(s.Items).Kind() != reflect.Slice {
+		return false
+	}
+
+	slice := reflect.ValueOf(s.Items)
+	for i := 0; i < slice.Len(); i++ {
+		if _, ok := slice.Index(i).Interface().(Actioner); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// actionsKey returns the key opening the action menu, the tab key being used when the keys don't set any.
+func (s *Select) actionsKey() Key {
+	if s.Keys.Actions.Code == 0 {
+		return Key{Code: KeyTab, Display: KeyTabDisplay}
+	}
+	return s.Keys.Actions
+}
+
+// actionMenu returns the action menu of the active item, rendered as a select listing its actions. Nil is
+// returned when the item has no actions or can't be selected.
+func (s *Select) actionMenu() *Select {
+	items, idx := s.list.Items()
+	if idx == list.NotFound || !s.list.CanSelect() {
+		return nil
+	}
+
+	actions := s.actions(items[idx])
+	if len(actions) == 0 {
+		return nil
+	}
+
+	size := s.Size
+	if len(actions) < size {
+		size = len(actions)
+	}
+
+	l, err := list.New(actions, size)
+	if err != nil {
+		return nil
+	}
+
+	menu := &Select{Label: items[idx], Templates: s.ActionTemplates, Keys: s.Keys, Size: size, list: l}
+
+	if menu.prepareTemplates() != nil {
+		return nil
+	}
+
+	return menu
+}
@@ -0,0 +1,98 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type fileEntry string
+
+func (f fileEntry) Actions() []string {
+	return []string{"open", "rename"}
+}
+
+func TestSelectActions(t *testing.T) {
+	items := []string{"notes.txt", "photos", "readme.md"}
+
+	actions := func(item interface{}) []string {
+		if item == "photos" {
+			return nil
+		}
+		return []string{"open", "copy", "delete"}
+	}
+
+	tcs := []struct {
+		name   string
+		input  string
+		index  int
+		action string
+	}{
+		{name: "when selecting an item", input: "\r", index: 0},
+		{name: "when choosing an action", input: "\t\x0e\x0e\r", index: 0, action: "delete"},
+		{name: "when choosing an action with vim keys", input: "jj\tj\r", index: 2, action: "copy"},
+		{name: "when closing the action menu", input: "\tj\t\r", index: 0},
+		{name: "when closing the action menu with the left key", input: "\t\x02j\r", index: 1},
+		{name: "when the item has no actions", input: "j\t\r", index: 1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Select{
+				Label:   "File",
+				Items:   items,
+				Actions: actions,
+				Stdin:   ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:  &closeBuffer{},
+			}
+
+			result, err := s.RunWithAction()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if result.Index != tc.index || result.Item != items[tc.index] || result.Action != tc.action {
+				t.Errorf("Expected %s at %d with action %q, got %v", items[tc.index], tc.index, tc.action, result)
+			}
+		})
+	}
+
+	t.Run("when items declare their actions", func(t *testing.T) {
+		stdout := &closeBuffer{}
+		s := Select{
+			Label:  "File",
+			Items:  []fileEntry{"notes.txt", "photos"},
+			Stdin:  ioutil.NopCloser(strings.NewReader("j\tj\r")),
+			Stdout: stdout,
+		}
+
+		result, err := s.RunWithAction()
+		if err != nil {
+			t.Fatalf("Unexpected error running select %v", err)
+		}
+
+		if result.Index != 1 || result.Action != "rename" {
+			t.Errorf("Expected photos with action rename, got %v", result)
+		}
+
+		output := stripCodes(stdout.String())
+		for _, exp := range []string{"tab shows actions", "? photos:", "▸ open", "▸ rename"} {
+			if !strings.Contains(output, exp) {
+				t.Errorf("Expected output to contain %q, got %q", exp, output)
+			}
+		}
+	})
+
+	t.Run("when the action templates are invalid", func(t *testing.T) {
+		s := Select{
+			Items:           items,
+			Actions:         actions,
+			ActionTemplates: &SelectTemplates{Active: "{{ . "},
+		}
+
+		_, err := s.RunWithAction()
+		if err == nil {
+			t.Errorf("Expected an error for the invalid action templates")
+		}
+	})
+}
@@ -3,9 +3,12 @@ package promptui
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
+
+	"github.com/lemotw/promptui/multidimlist"
 )
 
 const esc = "\033["
@@ -92,6 +95,16 @@ var FuncMap = template.FuncMap{
 	"isSlice":   isSlice,
 	"sliceLen":  sliceLen,
 	"sliceItem": sliceItem,
+
+	// node helpers
+	"nodeLabel": multidimlist.Label,
+}
+
+var codesRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// stripCodes removes the terminal escape codes from the given string, leaving only its visible text.
+func stripCodes(s string) string {
+	return codesRegexp.ReplaceAllString(s, "")
 }
 
 func upLine(n uint) string {
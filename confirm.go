@@ -0,0 +1,430 @@
+package promptui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chzyer/readline"
+	"github.com/lemotw/promptui/screenbuf"
+)
+
+// Confirm is a prompt asking a yes or no question. Unlike a Prompt with IsConfirm set, it returns the answer as
+// a boolean, answering no being a valid answer rather than an error.
+type Confirm struct {
+	// Label is the question displayed on the command line prompt. The IconInitial value "?" will be added
+	// automatically to the label so it does not need to be added.
+	Label interface{}
+
+	// Default is the answer given when enter is pressed without answering. It is also the answer selected
+	// when the toggle starts.
+	Default bool
+
+	// Yes are the words accepted as a yes answer, regardless of case. Defaults to "y" and "yes". The shortest
+	// and the longest words are displayed inside the prompt, so that other languages can be used.
+	Yes []string
+
+	// No are the words accepted as a no answer, regardless of case. Defaults to "n" and "no".
+	No []string
+
+	// SingleKey makes a single character answer, like "y" or "n", answer the question as soon as its key is
+	// pressed, without waiting for enter.
+	SingleKey bool
+
+	// Toggle displays both answers side by side instead of asking to type the answer. The left and right
+	// arrow keys move between them and enter chooses the highlighted one. The single character answers still
+	// select their answer.
+	Toggle bool
+
+	// Templates can be used to customize the confirm output. If nil is passed, the default templates are used.
+	// See the ConfirmTemplates docs for more info.
+	Templates *ConfirmTemplates
+
+	// the Pointer defines how to render the cursor
+	Pointer Pointer
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+
+	// HideEntered sets whether to hide the answer after the user has answered.
+	HideEntered bool
+
+	// IsVimMode enables vi-like movements (hjkl) and editing.
+	IsVimMode bool
+}
+
+// ConfirmState is the value given to the templates of a Confirm.
+type ConfirmState struct {
+	// Label is the label of the confirm.
+	Label interface{}
+
+	// Yes tells whether the answer is yes. It holds the default answer until the question is answered, and
+	// the answer highlighted by the toggle.
+	Yes bool
+
+	// Hint shows the shortest words of each answer, the default one being in upper case, like "[Y/n]".
+	Hint string
+
+	// YesLabel and NoLabel are the longest words of each answer starting with an upper case, like "Yes" and
+	// "No".
+	YesLabel string
+	NoLabel  string
+}
+
+// ConfirmTemplates allow a confirm to be customized following stdlib text/template syntax. The templates receive
+// a ConfirmState. See the PromptTemplates docs for more info on templates.
+type ConfirmTemplates struct {
+	// Compiled templates
+	prompt     *template.Template
+	toggle     *template.Template
+	success    *template.Template
+	validation *template.Template
+
+	// FuncMap is a map of helper functions that can be used inside of templates according to the text/template
+	// documentation.
+	//
+	// By default, FuncMap contains the color functions used to color the text in templates. If FuncMap
+	// is overridden, the colors functions must be added in the override from promptui.FuncMap to work.
+	FuncMap template.FuncMap
+
+	// Prompt is a text/template for the label displayed on the left side of the typed answer.
+	Prompt string
+
+	// Toggle is a text/template for the label and both answers when the confirm is a toggle.
+	Toggle string
+
+	// Success is a text/template for the label and the answer once the question has been answered.
+	Success string
+
+	// ValidationError is a text/template for the error displayed when the typed answer is neither yes nor no.
+	ValidationError string
+}
+
+// Run executes the confirm. It displays the question and waits for the user to answer it. It returns whether
+// the answer is yes, and an error if the confirm was canceled or if any other error occurred.
+func (c *Confirm) Run() (bool, error) {
+	c.setWords()
+
+	err := c.prepareTemplates()
+	if err != nil {
+		return false, err
+	}
+
+	cfg := &readline.Config{
+		Stdin:          c.Stdin,
+		Stdout:         c.Stdout,
+		HistoryLimit:   -1,
+		VimMode:        c.IsVimMode,
+		UniqueEditLine: true,
+	}
+
+	err = cfg.Init()
+	if err != nil {
+		return false, err
+	}
+
+	rl, err := readline.NewEx(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	rl.Write([]byte(hideCursor))
+	sb := screenbuf.New(rl)
+
+	cur := NewCursor("", c.Pointer, false)
+
+	state := c.state()
+
+	// picked tells whether the answer was chosen with a key rather than typed, and answerErr holds the
+	// error of an answer that is neither yes nor no until it is displayed
+	picked := false
+	var answerErr error
+
+	draw := func() {
+		if c.Toggle {
+			sb.Write(render(c.Templates.toggle, state))
+		} else {
+			prompt := render(c.Templates.prompt, state)
+			sb.Write(append(prompt, []byte(cur.Format())...))
+		}
+
+		if answerErr != nil {
+			sb.Write(render(c.Templates.validation, answerErr))
+			answerErr = nil
+		}
+
+		sb.Flush()
+	}
+
+	// mu guards the answer, as readline keeps handling keys once a line ends
+	var mu sync.Mutex
+
+	cfg.FuncFilterInputRune = func(key rune) (rune, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if key == 0 || stopsReading(key) {
+			return key, true
+		}
+
+		yes, ok := c.answerKey(key)
+		switch {
+		case ok && c.SingleKey:
+			state.Yes, picked = yes, true
+			return KeyEnter, true
+		case ok && c.Toggle:
+			state.Yes = yes
+		case c.Toggle && (key == KeyBackward || key == KeyForward):
+			state.Yes = key == KeyBackward
+		case c.Toggle:
+		default:
+			return key, true
+		}
+
+		// the toggle handles its keys by itself
+		draw()
+		return key, false
+	}
+
+	cfg.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		keepOn := true
+		if !c.Toggle {
+			_, _, keepOn = cur.Listen(line, pos, key)
+		}
+
+		draw()
+		return nil, 0, keepOn
+	})
+
+	for {
+		_, err = rl.Readline()
+		if err != nil {
+			break
+		}
+
+		mu.Lock()
+		done := c.Toggle || picked
+		if !done {
+			var yes bool
+			yes, done = c.answer(cur.Get())
+			if done {
+				state.Yes = yes
+			} else {
+				answerErr = fmt.Errorf("answer %s or %s", longest(c.Yes), longest(c.No))
+				cur.Replace("")
+			}
+		}
+		mu.Unlock()
+
+		if done {
+			break
+		}
+	}
+
+	if err != nil {
+		switch err {
+		case readline.ErrInterrupt:
+			err = ErrInterrupt
+		case io.EOF:
+			err = ErrEOF
+		}
+		if err.Error() == "Interrupt" {
+			err = ErrInterrupt
+		}
+		mu.Lock()
+		sb.Reset()
+		sb.WriteString("")
+		sb.Flush()
+		mu.Unlock()
+		rl.Write([]byte(showCursor))
+		rl.Close()
+		return false, err
+	}
+
+	mu.Lock()
+	answered := *state
+
+	if c.HideEntered {
+		clearScreen(sb)
+	} else {
+		sb.Reset()
+		sb.Write(render(c.Templates.success, &answered))
+		sb.Flush()
+	}
+	mu.Unlock()
+
+	rl.Write([]byte(showCursor))
+	rl.Close()
+
+	return answered.Yes, nil
+}
+
+// answer returns the answer matching the typed words, the default one when nothing was typed. It returns false
+// when the words are neither yes nor no.
+func (c *Confirm) answer(input string) (bool, bool) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return c.Default, true
+	}
+
+	for _, word := range c.Yes {
+		if strings.EqualFold(word, input) {
+			return true, true
+		}
+	}
+
+	for _, word := range c.No {
+		if strings.EqualFold(word, input) {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// answerKey returns the answer of the single character word matching the given key, if any.
+func (c *Confirm) answerKey(key rune) (bool, bool) {
+	if !unicode.IsPrint(key) {
+		return false, false
+	}
+
+	word := string(key)
+	for _, words := range [][]string{c.Yes, c.No} {
+		for _, w := range words {
+			if utf8.RuneCountInString(w) == 1 && strings.EqualFold(w, word) {
+				return c.answer(word)
+			}
+		}
+	}
+
+	return false, false
+}
+
+func (c *Confirm) setWords() {
+	if len(c.Yes) == 0 {
+		c.Yes = []string{"y", "yes"}
+	}
+
+	if len(c.No) == 0 {
+		c.No = []string{"n", "no"}
+	}
+}
+
+// state returns the state of the confirm before it is answered.
+func (c *Confirm) state() *ConfirmState {
+	yes, no := shortest(c.Yes), shortest(c.No)
+	if c.Default {
+		yes = strings.ToUpper(yes)
+	} else {
+		no = strings.ToUpper(no)
+	}
+
+	return &ConfirmState{
+		Label:    c.Label,
+		Yes:      c.Default,
+		Hint:     fmt.Sprintf("[%s/%s]", yes, no),
+		YesLabel: capitalize(longest(c.Yes)),
+		NoLabel:  capitalize(longest(c.No)),
+	}
+}
+
+func (c *Confirm) prepareTemplates() error {
+	tpls := c.Templates
+	if tpls == nil {
+		tpls = &ConfirmTemplates{}
+	}
+
+	if tpls.FuncMap == nil {
+		tpls.FuncMap = FuncMap
+	}
+
+	bold := Styler(FGBold)
+
+	if tpls.Prompt == "" {
+		tpls.Prompt = fmt.Sprintf(`%s {{ .Label | bold }}? {{ .Hint | faint }} `, bold(IconInitial))
+	}
+
+	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Prompt)
+	if err != nil {
+		return err
+	}
+
+	tpls.prompt = tpl
+
+	if tpls.Toggle == "" {
+		tpls.Toggle = fmt.Sprintf(`%s {{ .Label | bold }}? `+
+			`{{ if .Yes }}{{ .YesLabel | cyan | underline }}{{ else }}{{ .YesLabel | faint }}{{ end }} `+
+			`{{ "/" | faint }} `+
+			`{{ if .Yes }}{{ .NoLabel | faint }}{{ else }}{{ .NoLabel | cyan | underline }}{{ end }}`,
+			bold(IconInitial))
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Toggle)
+	if err != nil {
+		return err
+	}
+
+	tpls.toggle = tpl
+
+	if tpls.Success == "" {
+		tpls.Success = fmt.Sprintf(`{{ .Label | faint }}%s {{ if .Yes }}{{ .YesLabel }}{{ else }}{{ .NoLabel }}{{ end }}`,
+			Styler(FGFaint)("?"))
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Success)
+	if err != nil {
+		return err
+	}
+
+	tpls.success = tpl
+
+	if tpls.ValidationError == "" {
+		tpls.ValidationError = `{{ ">>" | red }} {{ . | red }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.ValidationError)
+	if err != nil {
+		return err
+	}
+
+	tpls.validation = tpl
+
+	c.Templates = tpls
+
+	return nil
+}
+
+// shortest returns the first of the shortest words.
+func shortest(words []string) string {
+	result := words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(word) < utf8.RuneCountInString(result) {
+			result = word
+		}
+	}
+	return result
+}
+
+// longest returns the first of the longest words.
+func longest(words []string) string {
+	result := words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(word) > utf8.RuneCountInString(result) {
+			result = word
+		}
+	}
+	return result
+}
+
+// capitalize returns the word starting with an upper case.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
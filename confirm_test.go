@@ -0,0 +1,71 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestConfirmRun(t *testing.T) {
+	tcs := []struct {
+		name    string
+		confirm Confirm
+		input   string
+		expect  bool
+		output  string
+	}{
+		{name: "when answering yes", input: "y\r", expect: true, output: "[y/N]"},
+		{name: "when answering a word", input: "YES\r", expect: true},
+		{name: "when answering no", confirm: Confirm{Default: true}, input: "no\r", expect: false, output: "[Y/n]"},
+		{name: "when using the default", confirm: Confirm{Default: true}, input: "\r", expect: true},
+		{name: "when answering again", input: "yep\ry\r", expect: true, output: ">> answer yes or no"},
+		{
+			name:    "when answering in another language",
+			confirm: Confirm{Yes: []string{"j", "ja"}, No: []string{"n", "nein"}},
+			input:   "ja\r",
+			expect:  true,
+			output:  "[j/N]",
+		},
+		{name: "when answering with a single key", confirm: Confirm{SingleKey: true}, input: "y", expect: true},
+		{name: "when toggling", confirm: Confirm{Toggle: true}, input: "\x02\r", expect: true, output: "Yes / No"},
+		{name: "when toggling back", confirm: Confirm{Toggle: true, Default: true}, input: "\x06\r", expect: false},
+		{name: "when toggling with a key", confirm: Confirm{Toggle: true}, input: "yx\r", expect: true},
+		{name: "when toggling with a single key", confirm: Confirm{Toggle: true, SingleKey: true}, input: "n", expect: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			c := tc.confirm
+			c.Label = "Delete"
+			c.Stdin = ioutil.NopCloser(strings.NewReader(tc.input))
+			c.Stdout = out
+
+			yes, err := c.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running confirm %v", err)
+			}
+
+			if yes != tc.expect {
+				t.Errorf("Expected the answer %t, got %t", tc.expect, yes)
+			}
+
+			if !strings.Contains(stripCodes(out.String()), tc.output) {
+				t.Errorf("Expected %q inside the output %q", tc.output, out.String())
+			}
+		})
+	}
+
+	t.Run("when canceled", func(t *testing.T) {
+		c := Confirm{
+			Label:  "Delete",
+			Stdin:  ioutil.NopCloser(strings.NewReader("\x03")),
+			Stdout: &closeBuffer{},
+		}
+
+		_, err := c.Run()
+		if err != ErrInterrupt {
+			t.Errorf("Expected ErrInterrupt, got %v", err)
+		}
+	})
+}
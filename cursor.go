@@ -1,6 +1,7 @@
 package promptui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -49,7 +50,20 @@ type Cursor struct {
 	input []rune
 	// Put the cursor before this slice
 	Position int
-	erase    bool
+	// AllowedRunes restricts the characters Listen accepts when typed to
+	// the ones it returns true for.
+	AllowedRunes func(r rune) bool
+	// MaxLength is the maximum number of characters Listen accepts when
+	// typed. There is no limit when zero.
+	MaxLength int
+	// Transform changes the input each time Listen accepts typed
+	// characters, like strings.ToLower to force lower case.
+	Transform func(input string) string
+	erase     bool
+	// the pattern the input follows, if any
+	pattern pattern
+	// why the last typed characters were rejected
+	rejected error
 }
 
 // NewCursor create a new cursor, with the DefaultCursor, the specified input,
@@ -67,6 +81,26 @@ func NewCursor(startinginput string, pointer Pointer, eraseDefault bool) Cursor
 	return cur
 }
 
+// SetPattern makes the input follow the given pattern, like "###-###-####" for a phone number or "9999-99-99"
+// for a date. The "#" and "9" characters of the pattern stand for a digit, "a" for a letter and "*" for a
+// letter or a digit, and only these characters are accepted when typed at their place. The other characters
+// of the pattern, or the characters preceded by a backslash, are inserted as the input reaches them and the
+// cursor skips over them. The current input is formatted following the pattern.
+func (c *Cursor) SetPattern(p string) {
+	input := c.Get()
+
+	c.pattern = parsePattern(p)
+	c.input = nil
+	c.Position = 0
+	c.Update(input)
+
+	if c.erase {
+		c.Start()
+	} else {
+		c.End()
+	}
+}
+
 func (c *Cursor) String() string {
 	return fmt.Sprintf(
 		"Cursor: %s, input %s, Position %d",
@@ -136,8 +170,16 @@ func (c *Cursor) FormatMask(mask rune) string {
 }
 
 // Update inserts newinput into the input []rune in the appropriate place.
-// The cursor is moved to the end of the inputed sequence.
+// The cursor is moved to the end of the inputed sequence. With a pattern, the
+// runes which don't fit the pattern are dropped.
 func (c *Cursor) Update(newinput string) {
+	if c.pattern != nil {
+		for _, r := range newinput {
+			c.insert(r)
+		}
+		return
+	}
+
 	a := c.input
 	b := []rune(newinput)
 	i := c.Position
@@ -146,6 +188,38 @@ func (c *Cursor) Update(newinput string) {
 	c.Move(len(b))
 }
 
+// insert inserts the rune at the cursor following the pattern, and returns
+// whether it fits the pattern. Typing the literal under the cursor skips it.
+func (c *Cursor) insert(r rune) bool {
+	i := c.Position
+	if c.pattern.literal(i) && c.pattern[i].r == r {
+		if i == len(c.input) {
+			c.input = append(c.input, r)
+		}
+		c.Move(1)
+		return true
+	}
+
+	// the runes after the cursor shift to the next slots
+	n := len(c.pattern.slots(c.input[:i]))
+	runes := c.pattern.slots(c.input)
+	runes = append(runes[:n], append([]rune{r}, runes[n:]...)...)
+
+	input := c.pattern.format(runes)
+	if len(c.pattern.slots(input)) != len(runes) {
+		return false
+	}
+
+	c.input = input
+	c.Place(c.pattern.position(n + 1))
+	return true
+}
+
+// complete returns whether all the slots of the pattern are filled.
+func (c *Cursor) complete() bool {
+	return len(c.pattern.slots(c.input)) == c.pattern.size()
+}
+
 // Get returns a copy of the input
 func (c *Cursor) Get() string {
 	return string(c.input)
@@ -159,6 +233,14 @@ func (c *Cursor) GetMask(mask rune) string {
 // Replace replaces the previous input with whatever is specified, and moves the
 // cursor to the end position
 func (c *Cursor) Replace(input string) {
+	if c.pattern != nil {
+		c.input = nil
+		c.Position = 0
+		c.Update(input)
+		c.End()
+		return
+	}
+
 	c.input = []rune(input)
 	c.End()
 }
@@ -167,6 +249,7 @@ func (c *Cursor) Replace(input string) {
 func (c *Cursor) Place(position int) {
 	c.Position = position
 	c.correctPosition()
+	c.skipLiterals(1)
 }
 
 // Move moves the cursor over in relative terms, by shift indices.
@@ -174,6 +257,34 @@ func (c *Cursor) Move(shift int) {
 	// delete the current cursor
 	c.Position = c.Position + shift
 	c.correctPosition()
+	c.skipLiterals(shift)
+}
+
+// skipLiterals moves the cursor off the literals of the pattern, in the
+// direction of the shift unless there is no slot left that way.
+func (c *Cursor) skipLiterals(shift int) {
+	if c.pattern == nil {
+		return
+	}
+
+	dir := 1
+	if shift < 0 {
+		dir = -1
+	}
+
+	i := c.Position
+	for i >= 0 && i < len(c.input) && c.pattern.literal(i) {
+		i += dir
+	}
+
+	if i < 0 {
+		i = c.Position
+		for i < len(c.input) && c.pattern.literal(i) {
+			i++
+		}
+	}
+
+	c.Position = i
 }
 
 // Backspace removes the rune that precedes the cursor
@@ -187,6 +298,10 @@ func (c *Cursor) Backspace() {
 		// Shrug
 		return
 	}
+	if c.pattern != nil {
+		c.backspacePattern()
+		return
+	}
 	if i == len(a) {
 		c.input = a[:i-1]
 	} else {
@@ -196,11 +311,82 @@ func (c *Cursor) Backspace() {
 	c.Move(-1)
 }
 
+// backspacePattern removes the rune of the slot preceding the cursor, the
+// runes after it shifting to the previous slots. The literals typed at the end
+// of the input are removed first.
+func (c *Cursor) backspacePattern() {
+	i := c.Position
+	if i == len(c.input) && c.pattern.literal(i-1) {
+		for i > 0 && c.pattern.literal(i-1) {
+			i--
+		}
+		c.input = c.input[:i]
+		c.Place(i)
+		return
+	}
+
+	n := len(c.pattern.slots(c.input[:i]))
+	if n == 0 {
+		return
+	}
+
+	runes := c.pattern.slots(c.input)
+	c.input = c.pattern.format(append(runes[:n-1], runes[n:]...))
+	c.Place(c.pattern.position(n - 1))
+}
+
+// Rejected returns why the characters typed with the last key handled by
+// Listen were rejected, or nil if they were accepted.
+func (c *Cursor) Rejected() error {
+	return c.rejected
+}
+
+// typeRunes inserts the typed runes following the AllowedRunes, MaxLength and
+// the pattern, and transforms the input if any of them was inserted.
+func (c *Cursor) typeRunes(runes []rune) {
+	typed := false
+	for _, r := range runes {
+		err := c.typeRune(r)
+		if err != nil {
+			c.rejected = err
+			continue
+		}
+		typed = true
+	}
+
+	if typed && c.Transform != nil {
+		c.input = []rune(c.Transform(c.Get()))
+		c.correctPosition()
+	}
+}
+
+// typeRune inserts the typed rune, or returns why it is rejected. The errors
+// don't tell the rune, as the input may be masked.
+func (c *Cursor) typeRune(r rune) error {
+	if c.AllowedRunes != nil && !c.AllowedRunes(r) {
+		return errors.New("this character is not allowed")
+	}
+
+	if c.MaxLength > 0 && len(c.input) >= c.MaxLength {
+		return fmt.Errorf("the value is limited to %d characters", c.MaxLength)
+	}
+
+	if c.pattern == nil {
+		c.Update(string(r))
+	} else if !c.insert(r) {
+		return fmt.Errorf("this character doesn't fit %s", c.pattern)
+	}
+
+	return nil
+}
+
 // Listen is a readline Listener that updates internal cursor state appropriately.
 func (c *Cursor) Listen(line []rune, pos int, key rune) ([]rune, int, bool) {
+	c.rejected = nil
+
 	if line != nil {
 		// no matter what, update our internal representation.
-		c.Update(string(line))
+		c.typeRunes(line)
 	}
 
 	switch key {
@@ -224,7 +410,8 @@ func (c *Cursor) Listen(line []rune, pos int, key rune) ([]rune, int, bool) {
 		if c.erase {
 			c.erase = false
 			c.Replace("")
-			c.Update(string(key))
+			c.rejected = nil
+			c.typeRunes([]rune{key})
 		}
 	}
 
@@ -1,6 +1,10 @@
 package promptui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
 
 func TestDefinedCursors(t *testing.T) {
 	t.Run("pipeCursor", func(t *testing.T) {
@@ -101,3 +105,157 @@ func TestCursor(t *testing.T) {
 		}
 	})
 }
+
+func TestCursorPattern(t *testing.T) {
+	type step struct {
+		update    string
+		backspace bool
+		move      int
+	}
+
+	tcs := []struct {
+		name     string
+		pattern  string
+		input    string
+		steps    []step
+		expect   string
+		complete bool
+	}{
+		{name: "inserts the literals", pattern: "###-###-####", steps: []step{{update: "5551234567"}}, expect: "555-123-4567|", complete: true},
+		{name: "drops the rejected runes", pattern: "9999-99-99", steps: []step{{update: "20x24/01"}}, expect: "2024-01|"},
+		{name: "accepts the literals", pattern: "9999-99-99", steps: []step{{update: "2024-01-3"}}, expect: "2024-01-3|"},
+		{name: "stops at the end", pattern: "##", steps: []step{{update: "123"}}, expect: "12|", complete: true},
+		{name: "starts with a literal", pattern: "(###) ###", steps: []step{{update: "555"}}, expect: "(555|"},
+		{name: "accepts letters", pattern: "aa-**", steps: []step{{update: "a1b2c"}}, expect: "ab-2c|", complete: true},
+		{name: "escapes the slots", pattern: `\#99`, steps: []step{{update: "12"}}, expect: "#12|", complete: true},
+		{name: "formats the input", pattern: "###-###", input: "123456", expect: "123-456|", complete: true},
+		{
+			name:    "removes the trailing literals",
+			pattern: "###-###",
+			steps:   []step{{update: "1234"}, {backspace: true}},
+			expect:  "123|",
+		},
+		{
+			name:    "removes the typed literals",
+			pattern: "###-###",
+			steps:   []step{{update: "123-"}, {backspace: true}},
+			expect:  "123|",
+		},
+		{
+			name:     "skips the literals backwards",
+			pattern:  "###-###",
+			steps:    []step{{update: "123456"}, {move: -3}, {move: -1}},
+			expect:   "12|3-456",
+			complete: true,
+		},
+		{
+			name:     "skips the literals forwards",
+			pattern:  "###-###",
+			steps:    []step{{update: "123456"}, {move: -4}, {move: 1}},
+			expect:   "123-|456",
+			complete: true,
+		},
+		{
+			name:     "shifts the runes when inserting",
+			pattern:  "###-###",
+			steps:    []step{{update: "12345"}, {move: -5}, {update: "0"}},
+			expect:   "10|2-345",
+			complete: true,
+		},
+		{
+			name:    "shifts the runes when removing",
+			pattern: "###-###",
+			steps:   []step{{update: "123456"}, {move: -3}, {backspace: true}},
+			expect:  "12|4-56",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor := Cursor{input: []rune(tc.input), Cursor: pipeCursor}
+			cursor.SetPattern(tc.pattern)
+
+			for _, s := range tc.steps {
+				switch {
+				case s.backspace:
+					cursor.Backspace()
+				case s.move != 0:
+					cursor.Move(s.move)
+				default:
+					cursor.Update(s.update)
+				}
+			}
+
+			if cursor.Format() != tc.expect {
+				t.Errorf("expected %q; found %q", tc.expect, cursor.Format())
+			}
+
+			if cursor.complete() != tc.complete {
+				t.Errorf("expected the pattern to be complete %t", tc.complete)
+			}
+		})
+	}
+}
+
+func TestCursorListen(t *testing.T) {
+	tcs := []struct {
+		name     string
+		cursor   Cursor
+		keys     string
+		expect   string
+		rejected string
+	}{
+		{name: "types the runes", keys: "abc", expect: "abc|"},
+		{
+			name:   "rejects the runes not allowed",
+			cursor: Cursor{AllowedRunes: unicode.IsDigit},
+			keys:   "1a2",
+			expect: "12|",
+		},
+		{
+			name:     "rejects the last rune not allowed",
+			cursor:   Cursor{AllowedRunes: unicode.IsDigit},
+			keys:     "12a",
+			expect:   "12|",
+			rejected: "this character is not allowed",
+		},
+		{
+			name:     "stops at the max length",
+			cursor:   Cursor{MaxLength: 3},
+			keys:     "abcd",
+			expect:   "abc|",
+			rejected: "the value is limited to 3 characters",
+		},
+		{name: "transforms the input", cursor: Cursor{Transform: strings.ToLower}, keys: "AbC", expect: "abc|"},
+		{
+			name:     "rejects the runes not fitting the pattern",
+			cursor:   Cursor{pattern: parsePattern("##-##")},
+			keys:     "12x",
+			expect:   "12|",
+			rejected: "this character doesn't fit ##-##",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor := tc.cursor
+			cursor.Cursor = pipeCursor
+
+			for _, key := range tc.keys {
+				cursor.Listen([]rune{key}, 0, key)
+			}
+
+			if cursor.Format() != tc.expect {
+				t.Errorf("expected %q; found %q", tc.expect, cursor.Format())
+			}
+
+			rejected := ""
+			if cursor.Rejected() != nil {
+				rejected = cursor.Rejected().Error()
+			}
+			if rejected != tc.rejected {
+				t.Errorf("expected the rejection %q; found %q", tc.rejected, rejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package promptui
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"text/template"
+
+	"github.com/lemotw/promptui/list"
+)
+
+// Group is a named set of items displayed under a common heading inside a GroupSelect.
+type Group struct {
+	// Name is the heading displayed on top of the group's items.
+	Name string
+
+	// Items are the items of the group. It expects a slice of any kind of values, like Select.Items.
+	Items interface{}
+}
+
+// GroupHeader is the value given to the header templates of a GroupSelect for each group heading.
+type GroupHeader struct {
+	// Name is the name of the group.
+	Name string
+	// Len is the number of items inside the group.
+	Len int
+	// Collapsed tells whether the items of the group are hidden.
+	Collapsed bool
+}
+
+// String returns the name of the group followed by its number of items.
+func (h *GroupHeader) String() string {
+	return fmt.Sprintf("%s (%d)", h.Name, h.Len)
+}
+
+// GroupSearcher is the function signature used to search the items of a GroupSelect. It receives the searched
+// term, the index of the group and the index of the item inside that group and should return whether the item
+// fits the searched term.
+type GroupSearcher func(input string, group, index int) bool
+
+// GroupSelect is a select list displaying its items under group headings. Groups can be collapsed and expanded
+// with a key or by pressing enter on their heading. Headings can be navigated to but are never returned as the
+// selected item.
+type GroupSelect struct {
+	// Label is the text displayed on top of the list to direct input. The IconInitial value "?" will be
+	// appended automatically to the label so it does not need to be added.
+	Label interface{}
+
+	// Groups are the groups of items to display inside the list. It expects either a slice of Group or a map
+	// of group names to slices of items, in which case the groups are sorted by name.
+	Groups interface{}
+
+	// Templates can be used to customize the group select output. If nil is passed, the default templates
+	// are used. See the GroupSelectTemplates docs for more info.
+	Templates *GroupSelectTemplates
+	// Keys is the set of keys used to control the interface. See the GroupSelectKeys docs for more info.
+	Keys *GroupSelectKeys
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+	// A function that determines how to render the cursor
+	Pointer Pointer
+	// Searcher is a function that can be implemented to search the items of the groups. Search keeps the
+	// headings of the groups with at least one matching item. It is unimplemented by default and search will
+	// not work unless it is implemented.
+	Searcher GroupSearcher
+
+	// Size is the number of rows, headings included, that should appear before scrolling is necessary.
+	// Defaults to 5.
+	Size int
+
+	// StartCollapsed sets whether all groups start collapsed.
+	StartCollapsed bool
+	// IsVimMode sets whether to use vim mode when using readline in the command prompt.
+	IsVimMode bool
+	// HideHelp sets whether to hide help information.
+	HideHelp bool
+	// HideSelected sets whether to hide the text displayed after an item is successfully selected.
+	HideSelected bool
+	// StartInSearchMode sets whether or not the select should start in search mode.
+	StartInSearchMode bool
+}
+
+// GroupSelectKeys defines the available keys used by a group select. It extends the SelectKeys with the key
+// used to collapse and expand the groups.
+type GroupSelectKeys struct {
+	SelectKeys
+
+	// Toggle is the key used to collapse or expand the group of the active item or heading. Defaults to the
+	// tab key.
+	Toggle Key
+}
+
+// GroupSelectTemplates allow a group select to be customized. The SelectTemplates are used for the items of
+// the groups while the header templates receive a GroupHeader for each group heading.
+type GroupSelectTemplates struct {
+	SelectTemplates
+
+	header       *template.Template
+	activeHeader *template.Template
+
+	// Header is a text/template for the heading of a group. Defaults to the group's name and number of items
+	// preceded by a collapse marker.
+	Header string
+
+	// ActiveHeader is a text/template for the heading of a group when it is under the cursor.
+	ActiveHeader string
+}
+
+type groupItems struct {
+	name  string
+	items []interface{}
+}
+
+// groupRow locates a row of the list inside the groups. Headings have a NotFound index.
+type groupRow struct {
+	group int
+	index int
+}
+
+// Run executes the group select. It displays the label and the groups of items, asking the user to chose any
+// item. It returns the index of the group, the index of the item inside that group, the item and an error if
+// any occurred during the select's execution.
+func (g *GroupSelect) Run() (int, int, interface{}, error) {
+	groups, err := groupsOf(g.Groups)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	if g.Size == 0 {
+		g.Size = 5
+	}
+
+	g.setKeys()
+
+	err = g.prepareTemplates()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	collapsed := make([]bool, len(groups))
+	for i := range collapsed {
+		collapsed[i] = g.StartCollapsed
+	}
+
+	var rows []groupRow
+
+	s := &Select{
+		Label:             g.Label,
+		Templates:         &g.Templates.SelectTemplates,
+		Keys:              &g.Keys.SelectKeys,
+		Stdin:             g.Stdin,
+		Stdout:            g.Stdout,
+		Pointer:           g.Pointer,
+		Size:              g.Size,
+		IsVimMode:         g.IsVimMode,
+		HideHelp:          g.HideHelp,
+		HideSelected:      g.HideSelected,
+		StartInSearchMode: g.StartInSearchMode,
+	}
+
+	if g.Searcher != nil {
+		s.Searcher = func(input string, i int) bool {
+			row := rows[i]
+			if row.index != list.NotFound {
+				return g.Searcher(input, row.group, row.index)
+			}
+
+			for j := range groups[row.group].items {
+				if g.Searcher(input, row.group, j) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	build := func() error {
+		rows = nil
+		var values []interface{}
+
+		for i, group := range groups {
+			rows = append(rows, groupRow{group: i, index: list.NotFound})
+			values = append(values, &GroupHeader{Name: group.name, Len: len(group.items), Collapsed: collapsed[i]})
+
+			if collapsed[i] {
+				continue
+			}
+
+			for j, item := range group.items {
+				rows = append(rows, groupRow{group: i, index: j})
+				values = append(values, item)
+			}
+		}
+
+		l, err := list.New(values, g.Size)
+		if err != nil {
+			return err
+		}
+		l.Searcher = s.Searcher
+		l.Labeler = s.labeler()
+
+		s.list = l
+		return nil
+	}
+
+	// toggle collapses or expands the group of the active row and keeps the cursor on its heading
+	toggle := func(term string) {
+		_, idx := s.list.Items()
+		if idx == list.NotFound {
+			return
+		}
+
+		group := rows[s.list.Index()].group
+		collapsed[group] = !collapsed[group]
+
+		if build() != nil {
+			return
+		}
+
+		if term != "" {
+			s.list.Search(term)
+		}
+
+		for i, row := range rows {
+			if row.group == group && row.index == list.NotFound {
+				s.list.SetIndex(i)
+				break
+			}
+		}
+	}
+
+	// entering a heading toggles its group instead of selecting it
+	s.onSubmit = func(item interface{}, term string) bool {
+		if _, ok := item.(*GroupHeader); !ok {
+			return true
+		}
+
+		toggle(term)
+		return false
+	}
+
+	s.onKey = func(key rune, term string, searching bool) bool {
+		if key != g.Keys.Toggle.Code {
+			return false
+		}
+
+		toggle(term)
+		return true
+	}
+
+	s.renderRow = func(item interface{}, active bool) ([]byte, bool) {
+		h, ok := item.(*GroupHeader)
+		if !ok {
+			return nil, false
+		}
+
+		if active {
+			return render(g.Templates.activeHeader, h), true
+		}
+		return render(g.Templates.header, h), true
+	}
+
+	err = build()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	// start on the first item rather than on the first heading
+	cursor := 0
+	for i, row := range rows {
+		if row.index != list.NotFound {
+			cursor = i
+			break
+		}
+	}
+
+	_, item, err := s.innerRun(cursor, 0, ' ')
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	row := rows[s.list.Index()]
+	if row.index == list.NotFound {
+		return 0, 0, nil, fmt.Errorf("group heading %v can't be selected", item)
+	}
+
+	return row.group, row.index, item, nil
+}
+
+func (g *GroupSelect) setKeys() {
+	if g.Keys != nil {
+		return
+	}
+
+	s := &Select{}
+	s.setKeys()
+
+	g.Keys = &GroupSelectKeys{
+		SelectKeys: *s.Keys,
+		Toggle:     Key{Code: KeyTab, Display: KeyTabDisplay},
+	}
+}
+
+func (g *GroupSelect) prepareTemplates() error {
+	tpls := g.Templates
+	if tpls == nil {
+		tpls = &GroupSelectTemplates{}
+	}
+
+	if tpls.Help == "" {
+		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} `+
+			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} `+
+			`{{ "%s" | faint }} {{ "toggles groups" | faint }}`+
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}`,
+			g.Keys.Toggle.Display)
+	}
+
+	s := &Select{Templates: &tpls.SelectTemplates}
+
+	err := s.prepareTemplates()
+	if err != nil {
+		return err
+	}
+
+	if tpls.Header == "" {
+		tpls.Header = `{{ if .Collapsed }}{{ "+" | faint }}{{ else }}{{ "-" | faint }}{{ end }} ` +
+			`{{ .Name | bold }} {{ printf "(%d)" .Len | faint }}`
+	}
+
+	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Header)
+	if err != nil {
+		return err
+	}
+
+	tpls.header = tpl
+
+	if tpls.ActiveHeader == "" {
+		tpls.ActiveHeader = fmt.Sprintf(`%s {{ .Name | bold | underline }} {{ printf "(%%d)" .Len | faint }}`, IconSelect)
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.ActiveHeader)
+	if err != nil {
+		return err
+	}
+
+	tpls.activeHeader = tpl
+
+	g.Templates = tpls
+
+	return nil
+}
+
+// groupsOf converts a slice of Group or a map of names to slices into groups of items.
+func groupsOf(groups interface{}) ([]groupItems, error) {
+	switch v := groups.(type) {
+	case []Group:
+		result := make([]groupItems, len(v))
+		for i, group := range v {
+			items, err := itemsOf(group.Items)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = groupItems{name: group.Name, items: items}
+		}
+		return result, nil
+	}
+
+	val := reflect.ValueOf(groups)
+	if groups == nil || val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("groups %v is neither a slice of groups nor a map of names to items", groups)
+	}
+
+	var names []string
+	for _, key := range val.MapKeys() {
+		names = append(names, key.String())
+	}
+	sort.Strings(names)
+
+	result := make([]groupItems, len(names))
+	for i, name := range names {
+		items, err := itemsOf(val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key())).Interface())
+		if err != nil {
+			return nil, err
+		}
+		result[i] = groupItems{name: name, items: items}
+	}
+
+	return result, nil
+}
+
+func itemsOf(items interface{}) ([]interface{}, error) {
+	if items == nil || reflect.TypeOf(items).Kind() != reflect.Slice {
+		return nil, fmt.Errorf("items %v is not a slice", items)
+	}
+
+	slice := reflect.ValueOf(items)
+	result := make([]interface{}, slice.Len())
+
+	for i := range result {
+		result[i] = slice.Index(i).Interface()
+	}
+
+	return result, nil
+}
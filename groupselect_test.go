@@ -0,0 +1,115 @@
+package promptui
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type closeBuffer struct {
+	bytes.Buffer
+}
+
+func (b *closeBuffer) Close() error {
+	return nil
+}
+
+func TestGroupSelectRun(t *testing.T) {
+	groups := map[string][]string{
+		"fruits":     {"apple", "banana"},
+		"vegetables": {"carrot", "leek"},
+	}
+	names := []string{"fruits", "vegetables"}
+
+	searcher := func(input string, group, index int) bool {
+		return strings.Contains(groups[names[group]][index], input)
+	}
+
+	tcs := []struct {
+		name      string
+		input     string
+		collapsed bool
+		group     int
+		index     int
+		item      string
+	}{
+		{name: "selects the first item", input: "\r", group: 0, index: 0, item: "apple"},
+		{name: "moves across headings", input: "jjjj\r", group: 1, index: 1, item: "leek"},
+		{name: "collapses the group of an item", input: "\tjj\r", group: 1, index: 0, item: "carrot"},
+		{name: "collapses with enter on a heading", input: "k\rjj\r", group: 1, index: 0, item: "carrot"},
+		{name: "expands with enter on a heading", input: "\rj\r", collapsed: true, group: 0, index: 0, item: "apple"},
+		{name: "expands with toggle on a heading", input: "j\tj\r", collapsed: true, group: 1, index: 0, item: "carrot"},
+		{name: "searches across groups", input: "/e\x0e\x0e\x0e\r", group: 1, index: 1, item: "leek"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := GroupSelect{
+				Label:          "Food",
+				Groups:         groups,
+				Searcher:       searcher,
+				StartCollapsed: tc.collapsed,
+				Stdin:          ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:         &closeBuffer{},
+			}
+
+			group, index, item, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running group select %v", err)
+			}
+
+			if group != tc.group || index != tc.index || item != tc.item {
+				t.Errorf("Expected %d, %d, %q, got %d, %d, %v", tc.group, tc.index, tc.item, group, index, item)
+			}
+		})
+	}
+
+	t.Run("when all groups are empty", func(t *testing.T) {
+		s := GroupSelect{
+			Label:  "Food",
+			Groups: []Group{{Name: "a", Items: []string{}}, {Name: "b", Items: []string{}}},
+			Stdin:  ioutil.NopCloser(strings.NewReader("\r\t\r")),
+			Stdout: &closeBuffer{},
+		}
+
+		_, _, _, err := s.Run()
+		if err != ErrEOF {
+			t.Errorf("Expected %v, got %v", ErrEOF, err)
+		}
+	})
+
+	t.Run("when groups are not valid", func(t *testing.T) {
+		s := GroupSelect{Groups: []string{"a"}}
+
+		_, _, _, err := s.Run()
+		if err == nil {
+			t.Errorf("Expected error got none")
+		}
+	})
+}
+
+func TestGroupSelectTemplateRender(t *testing.T) {
+	s := GroupSelect{Groups: []Group{}}
+	s.setKeys()
+
+	err := s.prepareTemplates()
+	if err != nil {
+		t.Fatalf("Unexpected error preparing templates %v", err)
+	}
+
+	header := &GroupHeader{Name: "fruits", Len: 2}
+
+	result := string(render(s.Templates.header, header))
+	exp := "\x1b[2m-\x1b[0m \x1b[1mfruits\x1b[0m \x1b[2m(2)\x1b[0m"
+	if result != exp {
+		t.Errorf("Expected header to eq %q, got %q", exp, result)
+	}
+
+	header.Collapsed = true
+	result = string(render(s.Templates.activeHeader, header))
+	exp = "\x1b[1m▸\x1b[0m \x1b[4m\x1b[1mfruits\x1b[0m \x1b[2m(2)\x1b[0m"
+	if result != exp {
+		t.Errorf("Expected active header to eq %q, got %q", exp, result)
+	}
+}
@@ -0,0 +1,109 @@
+package promptui
+
+import (
+	"reflect"
+
+	"github.com/lemotw/promptui/list"
+)
+
+// SelectHandle changes the items of a running Select, for example when a background job finishes or when an
+// item is deleted. Its methods can be called from any goroutine. The select displays the changes right away
+// and its cursor stays on the same item as long as it is listed.
+//
+// Indices are the indices of the items as they are after the previous changes, which are also the ones
+// received by the Searcher and returned by Run. The Items of the select are kept in step with the changes
+// before the search is applied again, so the Searcher should read the items from Items rather than from a
+// copy of them.
+type SelectHandle struct {
+	s *Select
+}
+
+// Handle returns a handle changing the items of the select while it runs. The handle can be taken before
+// calling Run, its methods returning ErrNotRunning until the select is displayed and once it returns.
+func (s *Select) Handle() *SelectHandle {
+	return &SelectHandle{s: s}
+}
+
+// Insert adds the given items at the given index.
+func (h *SelectHandle) Insert(index int, items ...interface{}) error {
+	return h.change(func(l *list.List) error {
+		return l.Insert(index, items...)
+	})
+}
+
+// Remove removes the item at the given index.
+func (h *SelectHandle) Remove(index int) error {
+	return h.change(func(l *list.List) error {
+		return l.Remove(index)
+	})
+}
+
+// Update replaces the item at the given index.
+func (h *SelectHandle) Update(index int, item interface{}) error {
+	return h.change(func(l *list.List) error {
+		return l.Update(index, item)
+	})
+}
+
+// Replace replaces all the items of the select. The items attribute must be a slice type.
+func (h *SelectHandle) Replace(items interface{}) error {
+	return h.change(func(l *list.List) error {
+		return l.Replace(items)
+	})
+}
+
+// change applies the given change to the list of the running select and renders it again. The hotkeys are
+// collected again from the changed items, an error being returned when they conflict.
+func (h *SelectHandle) change(apply func(l *list.List) error) error {
+	s := h.s
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.redraw == nil {
+		return ErrNotRunning
+	}
+
+	err := apply(s.list)
+	if err != nil {
+		return err
+	}
+
+	err = s.collectHotkeys(s.list.Values())
+	s.redraw()
+
+	return err
+}
+
+// setItems keeps Items in step with the items of the list, in a slice of the same type when the items allow it.
+func (s *Select) setItems(values []interface{}) {
+	s.Items = sliceLike(s.Items, values)
+}
+
+// sliceLike returns the values in a slice of the same type as the given one, or the values themselves if one of
+// them does not fit the type of its elements.
+func sliceLike(like interface{}, values []interface{}) interface{} {
+	t := reflect.TypeOf(like)
+	if t == nil || t.Kind() != reflect.Slice {
+		return values
+	}
+
+	slice := reflect.MakeSlice(t, len(values), len(values))
+	for i, value := range values {
+		if value == nil {
+			switch t.Elem().Kind() {
+			case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+				continue
+			}
+			return values
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(t.Elem()) {
+			return values
+		}
+		slice.Index(i).Set(v)
+	}
+
+	return slice.Interface()
+}
@@ -0,0 +1,135 @@
+package promptui
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectHandle(t *testing.T) {
+	r, w := io.Pipe()
+
+	s := &Select{
+		Label:  "Select Number",
+		Items:  []string{"one", "two", "three"},
+		Stdin:  r,
+		Stdout: &closeBuffer{},
+	}
+	h := s.Handle()
+
+	if err := h.Insert(0, "zero"); err != ErrNotRunning {
+		t.Fatalf("Expected ErrNotRunning before running, got %v", err)
+	}
+
+	type result struct {
+		index int
+		item  interface{}
+		err   error
+	}
+	done := make(chan result)
+
+	go func() {
+		index, item, err := s.Run()
+		done <- result{index, item, err}
+	}()
+
+	// wait for the select to be displayed
+	err := ErrNotRunning
+	for i := 0; i < 100 && err == ErrNotRunning; i++ {
+		time.Sleep(10 * time.Millisecond)
+		err = h.Insert(0, "zero")
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error inserting an item %v", err)
+	}
+
+	if err := h.Update(1, "uno"); err != nil {
+		t.Fatalf("Unexpected error updating an item %v", err)
+	}
+
+	if err := h.Remove(3); err != nil {
+		t.Fatalf("Unexpected error removing an item %v", err)
+	}
+
+	if err := h.Replace("zero"); err == nil {
+		t.Errorf("Expected an error replacing items with a string")
+	}
+
+	w.Write([]byte("\r"))
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("Unexpected error running select %v", res.err)
+		}
+
+		if res.index != 1 || res.item != "uno" {
+			t.Errorf("Expected uno at 1 to stay selected, got %v at %d", res.item, res.index)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the select to end")
+	}
+
+	if err := h.Remove(0); err != ErrNotRunning {
+		t.Errorf("Expected ErrNotRunning once the select ended, got %v", err)
+	}
+}
+
+func TestSelectHandleSearching(t *testing.T) {
+	r, w := io.Pipe()
+
+	s := &Select{
+		Label:  "Select Number",
+		Items:  []string{"one", "two", "three"},
+		Stdin:  r,
+		Stdout: &closeBuffer{},
+	}
+	s.Searcher = func(input string, index int) bool {
+		return strings.Contains(s.Items.([]string)[index], input)
+	}
+	h := s.Handle()
+
+	type result struct {
+		index int
+		item  interface{}
+		err   error
+	}
+	done := make(chan result)
+
+	go func() {
+		index, item, err := s.Run()
+		done <- result{index, item, err}
+	}()
+
+	w.Write([]byte("/o"))
+	time.Sleep(50 * time.Millisecond)
+
+	// the search is applied again to the inserted item
+	if err := h.Insert(3, "four"); err != nil {
+		t.Fatalf("Unexpected error inserting an item %v", err)
+	}
+
+	if err := h.Update(0, "uno"); err != nil {
+		t.Fatalf("Unexpected error updating an item %v", err)
+	}
+
+	w.Write([]byte("\x0e\x0e\r"))
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("Unexpected error running select %v", res.err)
+		}
+
+		if res.index != 3 || res.item != "four" {
+			t.Errorf("Expected four at 3 to be selected, got %v at %d", res.item, res.index)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the select to end")
+	}
+
+	if items, ok := s.Items.([]string); !ok || len(items) != 4 || items[0] != "uno" {
+		t.Errorf("Expected the items to follow the changes, got %v", s.Items)
+	}
+}
@@ -0,0 +1,115 @@
+package promptui
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+)
+
+// Hotkeyer can be implemented by the items of a select to declare the key that selects them immediately,
+// like the "d" of a "delete" entry inside a menu. A zero rune means the item has no hotkey.
+type Hotkeyer interface {
+	Hotkey() rune
+}
+
+// HotkeyFunc is a function that returns the key selecting the given item immediately. It is used for items
+// that don't implement the Hotkeyer interface. A zero rune means the item has no hotkey.
+type HotkeyFunc func(item interface{}) rune
+
+// prepareHotkeys collects the hotkeys declared by the items of the select, returning an error when two items
+// share the same hotkey or when a hotkey is used for searching or for quick selection. Hotkeys are matched
+// regardless of their case.
+func (s *Select) prepareHotkeys() error {
+	return s.collectHotkeys(s.Items)
+}
+
+// collectHotkeys collects the hotkeys declared by the given items, which change while a select runs. The
+// select has no hotkeys when an error is returned.
+func (s *Select) collectHotkeys(items interface{}) error {
+	s.hotkeys = nil
+
+	if items == nil || reflect.TypeOf(items).Kind() != reflect.Slice {
+		return nil
+	}
+
+	slice := reflect.ValueOf(items)
+
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i).Interface()
+
+		// items that can't be selected have no hotkey
+		key := s.hotkey(item)
+		if key == 0 || !s.selectable(item) {
+			continue
+		}
+
+		if s.hotkeys == nil {
+			s.hotkeys = make(map[rune]int)
+		}
+
+		var err error
+		if j, ok := s.hotkeys[key]; ok {
+			err = fmt.Errorf("hotkey %q is used by both items %d and %d", key, j, i)
+		} else if s.Searcher != nil && s.Keys != nil && key == unicode.ToLower(s.Keys.Search.Code) {
+			err = fmt.Errorf("hotkey %q of item %d is used for searching", key, i)
+		} else if s.QuickSelect != QuickSelectNone && key >= '1' && key <= '9' {
+			err = fmt.Errorf("hotkey %q of item %d is used for quick selection", key, i)
+		}
+
+		if err != nil {
+			s.hotkeys = nil
+			return err
+		}
+
+		s.hotkeys[key] = i
+	}
+
+	return nil
+}
+
+func (s *Select) hotkey(item interface{}) rune {
+	var key rune
+
+	if h, ok := item.(Hotkeyer); ok {
+		key = h.Hotkey()
+	} else if s.HotkeyFunc != nil {
+		key = s.HotkeyFunc(item)
+	}
+
+	return unicode.ToLower(key)
+}
+
+// plainLabel displays the item without highlighting its hotkey.
+func plainLabel(item interface{}) string {
+	return fmt.Sprintf("%v", item)
+}
+
+// hotkeyLabel displays the item with its hotkey surrounded by brackets. The first occurrence of the hotkey inside
+// the label is used, otherwise the hotkey is displayed in front of the label.
+func (s *Select) hotkeyLabel(item interface{}) string {
+	label := fmt.Sprintf("%v", item)
+
+	key := s.hotkey(item)
+	if key == 0 {
+		return label
+	}
+
+	runes := []rune(label)
+	for i, r := range runes {
+		if unicode.ToLower(r) == key {
+			return string(runes[:i]) + "[" + string(r) + "]" + string(runes[i+1:])
+		}
+	}
+
+	return "[" + string(key) + "] " + label
+}
+
+// hotkeyItem returns the index of the item selected by the given key, if any.
+func (s *Select) hotkeyItem(key rune) (int, bool) {
+	if len(s.hotkeys) == 0 {
+		return 0, false
+	}
+
+	i, ok := s.hotkeys[unicode.ToLower(key)]
+	return i, ok
+}
@@ -0,0 +1,170 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type menuEntry struct {
+	Name string
+	Key  rune
+}
+
+func (m menuEntry) Hotkey() rune {
+	return m.Key
+}
+
+func (m menuEntry) String() string {
+	return m.Name
+}
+
+func TestSelectHotkeys(t *testing.T) {
+	t.Run("when items implement Hotkeyer", func(t *testing.T) {
+		s := Select{
+			Items: []menuEntry{{Name: "edit", Key: 'e'}, {Name: "delete", Key: 'D'}, {Name: "quit"}},
+		}
+
+		err := s.prepareHotkeys()
+		if err != nil {
+			t.Fatalf("Unexpected error preparing hotkeys %v", err)
+		}
+
+		if i, ok := s.hotkeyItem('d'); !ok || i != 1 {
+			t.Errorf("Expected hotkey d to select item 1, got %d (%t)", i, ok)
+		}
+
+		if i, ok := s.hotkeyItem('E'); !ok || i != 0 {
+			t.Errorf("Expected hotkey E to select item 0, got %d (%t)", i, ok)
+		}
+
+		if _, ok := s.hotkeyItem('q'); ok {
+			t.Errorf("Expected hotkey q to select no item")
+		}
+	})
+
+	t.Run("when using a HotkeyFunc", func(t *testing.T) {
+		s := Select{
+			Items: []string{"edit", "delete"},
+			HotkeyFunc: func(item interface{}) rune {
+				return []rune(item.(string))[0]
+			},
+		}
+
+		err := s.prepareHotkeys()
+		if err != nil {
+			t.Fatalf("Unexpected error preparing hotkeys %v", err)
+		}
+
+		if i, ok := s.hotkeyItem('d'); !ok || i != 1 {
+			t.Errorf("Expected hotkey d to select item 1, got %d (%t)", i, ok)
+		}
+	})
+
+	t.Run("when a hotkey is the search key", func(t *testing.T) {
+		s := Select{
+			Items:    []menuEntry{{Name: "slash", Key: '/'}},
+			Searcher: func(string, int) bool { return true },
+		}
+		s.setKeys()
+
+		err := s.prepareHotkeys()
+		if err == nil || !strings.Contains(err.Error(), "searching") {
+			t.Errorf("Expected search key error, got %v", err)
+		}
+	})
+
+	t.Run("when a hotkey is a quick select number", func(t *testing.T) {
+		s := Select{
+			Items:       []menuEntry{{Name: "one", Key: '1'}},
+			QuickSelect: QuickSelectJump,
+		}
+		s.setKeys()
+
+		err := s.prepareHotkeys()
+		if err == nil || !strings.Contains(err.Error(), "quick selection") {
+			t.Errorf("Expected quick select error, got %v", err)
+		}
+	})
+
+	t.Run("when hotkeys conflict", func(t *testing.T) {
+		s := Select{
+			Items: []menuEntry{{Name: "delete", Key: 'd'}, {Name: "duplicate", Key: 'D'}},
+		}
+
+		err := s.prepareHotkeys()
+		if err == nil || !strings.Contains(err.Error(), "items 0 and 1") {
+			t.Errorf("Expected conflict error, got %v", err)
+		}
+	})
+}
+
+func TestSelectHotkeyTemplates(t *testing.T) {
+	items := []menuEntry{{Name: "edit", Key: 'e'}, {Name: "rename", Key: 'n'}, {Name: "copy", Key: 'x'}}
+	s := Select{
+		Label: "Action",
+		Items: items,
+	}
+
+	err := s.prepareHotkeys()
+	if err != nil {
+		t.Fatalf("Unexpected error preparing hotkeys %v", err)
+	}
+
+	err = s.prepareTemplates()
+	if err != nil {
+		t.Fatalf("Unexpected error preparing templates %v", err)
+	}
+
+	result := string(render(s.Templates.active, items[0]))
+	exp := "\x1b[1m▸\x1b[0m \x1b[4m[e]dit\x1b[0m"
+	if result != exp {
+		t.Errorf("Expected active item to eq %q, got %q", exp, result)
+	}
+
+	result = string(render(s.Templates.inactive, items[1]))
+	exp = "  re[n]ame"
+	if result != exp {
+		t.Errorf("Expected inactive item to eq %q, got %q", exp, result)
+	}
+
+	result = string(render(s.Templates.inactive, items[2]))
+	exp = "  [x] copy"
+	if result != exp {
+		t.Errorf("Expected inactive item to eq %q, got %q", exp, result)
+	}
+}
+
+func TestSelectHotkeyRun(t *testing.T) {
+	items := []menuEntry{{Name: "alpha"}, {Name: "axe"}, {Name: "copy", Key: 'x'}}
+
+	tcs := []struct {
+		name  string
+		input string
+		index int
+	}{
+		{name: "when pressing a hotkey", input: "x", index: 2},
+		{name: "when typing the label of an item with a hotkey", input: "c\r", index: 2},
+		{name: "when typing a hotkey inside a label", input: "ax\r", index: 1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Select{
+				Label:  "Action",
+				Items:  items,
+				Stdin:  ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout: &closeBuffer{},
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if index != tc.index {
+				t.Errorf("Expected index %d, got %d", tc.index, index)
+			}
+		})
+	}
+}
@@ -26,4 +26,41 @@ var (
 	// KeyForward is the default key to page down during selection.
 	KeyForward        rune = readline.CharForward
 	KeyForwardDisplay      = "→"
+
+	// KeyTab is the default key to collapse and expand groups during selection and to open the action menu
+	// of an item.
+	KeyTab        rune = readline.CharTab
+	KeyTabDisplay      = "tab"
+
+	// KeySort is the default key to change the order of a sortable select.
+	KeySort        rune = 15
+	KeySortDisplay      = "ctrl+o"
+
+	// KeyPreviewUp is the default key to scroll the preview pane of a select up.
+	KeyPreviewUp        rune = readline.CharCtrlY
+	KeyPreviewUpDisplay      = "ctrl+y"
+
+	// KeyPreviewDown is the default key to scroll the preview pane of a select down.
+	KeyPreviewDown        rune = readline.CharLineEnd
+	KeyPreviewDownDisplay      = "ctrl+e"
+
+	// KeyReveal is the default key to show and hide the input of a password.
+	KeyReveal        rune = readline.CharBckSearch
+	KeyRevealDisplay      = "ctrl+r"
+
+	// KeyAdd is the default key to add an item inside a SelectWithAdd.
+	KeyAdd        rune = readline.CharLineStart
+	KeyAddDisplay      = "ctrl+a"
+
+	// KeyRename is the default key to rename an item inside a SelectWithAdd.
+	KeyRename        rune = readline.CharLineEnd
+	KeyRenameDisplay      = "ctrl+e"
+
+	// KeyDelete is the default key to delete an item inside a SelectWithAdd.
+	KeyDelete        rune = readline.CharKill
+	KeyDeleteDisplay      = "ctrl+k"
+
+	// KeyCancel is the default key to cancel the edition of an item inside a SelectWithAdd.
+	KeyCancel        rune = readline.CharBell
+	KeyCancelDisplay      = "ctrl+g"
 )
@@ -4,7 +4,10 @@ package list
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 // Searcher is a base function signature that is used inside select when activating the search mode.
@@ -12,6 +15,22 @@ import (
 // the item fits the searched term.
 type Searcher func(input string, index int) bool
 
+// Labeler is a function signature used to jump to an item by typing the beginning of its label. It should
+// return the text displayed for the given item.
+type Labeler func(item interface{}) string
+
+// Selectable is a function signature used to tell whether an item can be selected. Items that can't be
+// selected, like separators or section headers, are still displayed but the cursor skips over them.
+type Selectable func(item interface{}) bool
+
+// Changed is a function signature used to follow the changes of the items. It receives all the items of the
+// list once they changed.
+type Changed func(items []interface{})
+
+// Less is a function signature used to sort the displayed items. It should return whether the first item
+// is displayed before the second one.
+type Less func(a, b interface{}) bool
+
 // NotFound is an index returned when no item was selected. This could
 // happen due to a search without results.
 const NotFound = -1
@@ -19,13 +38,28 @@ const NotFound = -1
 // List holds a collection of items that can be displayed with an N number of
 // visible items. The list can be moved up, down by one item of time or an
 // entire page (ie: visible size). It keeps track of the current selected item.
+//
+// The items can be changed with Insert, Remove, Update and Replace while the list is used, including from
+// other goroutines: all the methods of the list are safe for concurrent use. The searcher, labeler,
+// selectable and change functions are called while the list is locked, so they must not call the methods of
+// the list.
 type List struct {
+	// mu guards the list against concurrent changes
+	mu sync.Mutex
+
 	// items holds the full list of items
 	items []*interface{}
-	// scope holds the current visible or filtered items
+	// scope holds the current visible or filtered items, in their sorted order
 	scope []*interface{}
 	// Searcher is the function used for filtering items
 	Searcher Searcher
+	// Labeler is the function used for jumping to items by their label
+	Labeler Labeler
+	// IsSelectable is the function used for skipping items that can't be selected
+	IsSelectable Selectable
+	// OnChange is the function called when the items change, before the search is applied to them again, so
+	// that the items indexed by the searcher can be kept in step
+	OnChange Changed
 
 	// cursor holds the index of the current selected item
 	cursor int
@@ -33,6 +67,12 @@ type List struct {
 	size int
 	// start is the index of the first visible item
 	start int
+	// term is the searched term, applied again when the items change
+	term string
+	// searching tells whether the list is filtered by the term
+	searching bool
+	// less sorts the scope, which follows the order of the items when nil
+	less Less
 	// padding occurs here
 }
 
@@ -62,8 +102,17 @@ func New(items interface{}, size int) (*List, error) {
 // view, the new select item becomes the last visible item. If the list is
 // already at the top, nothing happens.
 func (l *List) Prev() {
-	if l.cursor > 0 {
-		l.cursor--
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i := l.nearest(l.cursor-1, -1); i != NotFound {
+		l.cursor = i
+	} else if start := l.cursor - l.size + 1; start < l.start {
+		// reveal the items above the cursor that can't be selected
+		if start < 0 {
+			start = 0
+		}
+		l.start = start
 	}
 
 	if l.start > l.cursor {
@@ -74,18 +123,32 @@ func (l *List) Prev() {
 // Search allows the list to be filtered by a given term. The list must
 // implement the searcher function signature for this functionality to work.
 func (l *List) Search(term string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	term = strings.Trim(term, " ")
 	l.cursor = 0
 	l.start = 0
+	l.term = term
+	l.searching = true
 	l.search(term)
+	l.sort()
+	l.settle(1)
 }
 
 // CancelSearch stops the current search and returns the list to its
 // original order.
 func (l *List) CancelSearch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	l.cursor = 0
 	l.start = 0
+	l.term = ""
+	l.searching = false
 	l.scope = l.items
+	l.sort()
+	l.settle(1)
 }
 
 func (l *List) search(term string) {
@@ -100,14 +163,97 @@ func (l *List) search(term string) {
 	l.scope = scope
 }
 
+// Sort displays the items in the order given by the less function, the cursor staying on the selected item.
+// The items keep their index inside the full list, as returned by Index, and the order of Values. A nil less
+// function displays the items in their original order again.
+func (l *List) Sort(less Less) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.less = less
+	l.change(l.items, nil)
+}
+
+// sort orders the scope with the less function. The items of the full list are left untouched.
+func (l *List) sort() {
+	if l.less == nil {
+		return
+	}
+
+	scope := append([]*interface{}{}, l.scope...)
+	sort.SliceStable(scope, func(i, j int) bool {
+		return l.less(*scope[i], *scope[j])
+	})
+
+	l.scope = scope
+}
+
+// JumpTo moves the cursor to the next item whose label starts with the given prefix, ignoring case. The list
+// must implement the labeler function signature for this functionality to work.
+//
+// A single character prefix starts looking after the selected item so that typing the same character again
+// cycles through the matching items, while a longer prefix keeps the selected item if it still matches. It
+// returns whether a matching item was found.
+func (l *List) JumpTo(prefix string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.Labeler == nil || prefix == "" || len(l.scope) == 0 {
+		return false
+	}
+
+	prefix = strings.ToLower(prefix)
+
+	if utf8.RuneCountInString(prefix) > 1 {
+		if l.jump(prefix, l.cursor) {
+			return true
+		}
+
+		// typing the same character repeatedly cycles through the items starting with it
+		first, _ := utf8.DecodeRuneInString(prefix)
+		if strings.Count(prefix, string(first)) != utf8.RuneCountInString(prefix) {
+			return false
+		}
+		prefix = string(first)
+	}
+
+	return l.jump(prefix, l.cursor+1)
+}
+
+func (l *List) jump(prefix string, from int) bool {
+	max := len(l.scope)
+
+	for i := 0; i < max; i++ {
+		j := (from + i) % max
+		if !l.selectable(j) {
+			continue
+		}
+
+		label := strings.ToLower(l.Labeler(*l.scope[j]))
+
+		if strings.HasPrefix(label, prefix) {
+			l.setCursor(j)
+			return true
+		}
+	}
+
+	return false
+}
+
 // Start returns the current render start position of the list.
 func (l *List) Start() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	return l.start
 }
 
 // SetStart sets the current scroll position. Values out of bounds will be
 // clamped.
 func (l *List) SetStart(i int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	if i < 0 {
 		i = 0
 	}
@@ -121,6 +267,13 @@ func (l *List) SetStart(i int) {
 // SetCursor sets the position of the cursor in the list. Values out of bounds
 // will be clamped.
 func (l *List) SetCursor(i int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setCursor(i)
+}
+
+func (l *List) setCursor(i int) {
 	max := len(l.scope) - 1
 	if i >= max {
 		i = max
@@ -135,16 +288,25 @@ func (l *List) SetCursor(i int) {
 	} else if l.start+l.size <= l.cursor {
 		l.start = l.cursor - l.size + 1
 	}
+
+	l.settle(1)
 }
 
 // Next moves the visible list forward one item. If the selected item is out of
 // view, the new select item becomes the first visible item. If the list is
 // already at the bottom, nothing happens.
 func (l *List) Next() {
-	max := len(l.scope) - 1
-
-	if l.cursor < max {
-		l.cursor++
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i := l.nearest(l.cursor+1, 1); i != NotFound {
+		l.cursor = i
+	} else if start := len(l.scope) - l.size; start > l.start {
+		// reveal the items below the cursor that can't be selected
+		if start > l.cursor {
+			start = l.cursor
+		}
+		l.start = start
 	}
 
 	if l.start+l.size <= l.cursor {
@@ -157,6 +319,9 @@ func (l *List) Next() {
 // If the list is already at the bottom, the selected item becomes the last
 // visible item.
 func (l *List) PageUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	start := l.start - l.size
 	if start < 0 {
 		l.start = 0
@@ -169,12 +334,17 @@ func (l *List) PageUp() {
 	if cursor < l.cursor {
 		l.cursor = cursor
 	}
+
+	l.settle(1)
 }
 
 // PageDown moves the visible list forward by x items. Where x is the size of
 // the visible items on the list. The selected item becomes the first visible
 // item.
 func (l *List) PageDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	start := l.start + l.size
 	max := len(l.scope) - l.size
 
@@ -194,22 +364,123 @@ func (l *List) PageDown() {
 	} else if cursor > l.cursor {
 		l.cursor = cursor
 	}
+
+	l.settle(1)
+}
+
+// MoveUp moves the selected item one position up inside the list, the cursor and the visible items following
+// it. It returns false if the item is already the first one or while the list is searched or sorted.
+func (l *List) MoveUp() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.move(-1)
+}
+
+// MoveDown moves the selected item one position down inside the list, the cursor and the visible items
+// following it. It returns false if the item is already the last one or while the list is searched or sorted.
+func (l *List) MoveDown() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.move(1)
+}
+
+func (l *List) move(dir int) bool {
+	i, j := l.cursor, l.cursor+dir
+	if l.searching || l.less != nil || i >= len(l.items) || j < 0 || j >= len(l.items) {
+		return false
+	}
+
+	// the scope is the full list when it is neither searched nor sorted
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+	l.cursor = j
+
+	if l.start > l.cursor {
+		l.start = l.cursor
+	} else if l.start+l.size <= l.cursor {
+		l.start = l.cursor - l.size + 1
+	}
+
+	return true
 }
 
 // CanPageDown returns whether a list can still PageDown().
 func (l *List) CanPageDown() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	max := len(l.scope)
 	return l.start+l.size < max
 }
 
 // CanPageUp returns whether a list can still PageUp().
 func (l *List) CanPageUp() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	return l.start > 0
 }
 
+// CanSelect returns whether the item under the cursor can be selected. It is false when the list is empty
+// or when none of its items can be selected.
+func (l *List) CanSelect() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.cursor < len(l.scope) && l.selectable(l.cursor)
+}
+
+func (l *List) selectable(i int) bool {
+	return l.IsSelectable == nil || l.IsSelectable(*l.scope[i])
+}
+
+// nearest returns the position of the first selectable item starting from i in the given direction, or
+// NotFound if there is none.
+func (l *List) nearest(i, dir int) int {
+	for ; i >= 0 && i < len(l.scope); i += dir {
+		if l.selectable(i) {
+			return i
+		}
+	}
+
+	return NotFound
+}
+
+// settle moves the cursor to the closest selectable item, looking in the given direction first, and scrolls
+// the list to keep it visible.
+func (l *List) settle(dir int) {
+	if l.IsSelectable == nil || len(l.scope) == 0 {
+		return
+	}
+
+	i := l.nearest(l.cursor, dir)
+	if i == NotFound {
+		i = l.nearest(l.cursor, -dir)
+	}
+	if i == NotFound {
+		return
+	}
+
+	l.cursor = i
+
+	if l.start > l.cursor {
+		l.start = l.cursor
+	} else if l.start+l.size <= l.cursor {
+		l.start = l.cursor - l.size + 1
+	}
+}
+
 // Index returns the index of the item currently selected inside the searched list. If no item is selected,
 // the NotFound (-1) index is returned.
 func (l *List) Index() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cursor >= len(l.scope) {
+		return NotFound
+	}
+
 	selected := l.scope[l.cursor]
 
 	for i, item := range l.items {
@@ -221,9 +492,34 @@ func (l *List) Index() int {
 	return NotFound
 }
 
+// SetIndex moves the cursor to the item at the given index of the full list, as returned by Index. It
+// returns false if the item is filtered out by the current search.
+func (l *List) SetIndex(index int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if index < 0 || index >= len(l.items) {
+		return false
+	}
+
+	selected := l.items[index]
+
+	for i, item := range l.scope {
+		if item == selected {
+			l.setCursor(i)
+			return true
+		}
+	}
+
+	return false
+}
+
 // Items returns a slice equal to the size of the list with the current visible
 // items and the index of the active item in this list.
 func (l *List) Items() ([]interface{}, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	var result []interface{}
 	max := len(l.scope)
 	end := l.start + l.size
@@ -244,3 +540,166 @@ func (l *List) Items() ([]interface{}, int) {
 
 	return result, active
 }
+
+// Len returns the number of items of the list, including the ones filtered out by the current search.
+func (l *List) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.items)
+}
+
+// Values returns all the items of the list, including the ones filtered out by the current search.
+func (l *List) Values() []interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.values()
+}
+
+func (l *List) values() []interface{} {
+	values := make([]interface{}, len(l.items))
+	for i, item := range l.items {
+		values[i] = *item
+	}
+
+	return values
+}
+
+// Insert adds the given items at the given index of the full list, as returned by Index. The cursor stays
+// on the selected item.
+func (l *List) Insert(index int, items ...interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if index < 0 || index > len(l.items) {
+		return fmt.Errorf("index %d is out of the list", index)
+	}
+
+	values := make([]*interface{}, 0, len(l.items)+len(items))
+	values = append(values, l.items[:index]...)
+	for i := range items {
+		values = append(values, &items[i])
+	}
+	values = append(values, l.items[index:]...)
+
+	l.change(values, nil)
+
+	return nil
+}
+
+// Remove removes the item at the given index of the full list, as returned by Index. The cursor stays on
+// the selected item, or takes the place of the removed one.
+func (l *List) Remove(index int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if index < 0 || index >= len(l.items) {
+		return fmt.Errorf("index %d is out of the list", index)
+	}
+
+	values := make([]*interface{}, 0, len(l.items)-1)
+	values = append(values, l.items[:index]...)
+	values = append(values, l.items[index+1:]...)
+
+	l.change(values, nil)
+
+	return nil
+}
+
+// Update replaces the item at the given index of the full list, as returned by Index. The current search
+// is applied to the updated item again.
+func (l *List) Update(index int, item interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if index < 0 || index >= len(l.items) {
+		return fmt.Errorf("index %d is out of the list", index)
+	}
+
+	*l.items[index] = item
+	l.change(l.items, nil)
+
+	return nil
+}
+
+// Replace replaces all the items of the list. The items attribute must be a slice type. The cursor moves to
+// the first new item equal to the selected one, or keeps its position if there is none.
+func (l *List) Replace(items interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if items == nil || reflect.TypeOf(items).Kind() != reflect.Slice {
+		return fmt.Errorf("items %v is not a slice", items)
+	}
+
+	slice := reflect.ValueOf(items)
+	values := make([]*interface{}, slice.Len())
+
+	for i := range values {
+		item := slice.Index(i).Interface()
+		values[i] = &item
+	}
+
+	l.change(values, func(selected *interface{}) *interface{} {
+		for _, item := range values {
+			if reflect.DeepEqual(*item, *selected) {
+				return item
+			}
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// change sets the items of the list and applies the current search to them again. The cursor stays on the
+// selected item, found by same when given, at the same distance from the top of the visible items. It keeps
+// its position when the selected item is gone.
+func (l *List) change(values []*interface{}, same func(selected *interface{}) *interface{}) {
+	var selected *interface{}
+	if l.cursor < len(l.scope) {
+		selected = l.scope[l.cursor]
+	}
+	offset := l.cursor - l.start
+
+	l.items = values
+	l.scope = values
+	if l.OnChange != nil {
+		l.OnChange(l.values())
+	}
+	if l.searching && l.Searcher != nil {
+		l.search(l.term)
+	}
+	l.sort()
+
+	if selected != nil && same != nil {
+		selected = same(selected)
+	}
+
+	cursor := l.cursor
+	for i, item := range l.scope {
+		if item == selected {
+			cursor = i
+			break
+		}
+	}
+
+	if max := len(l.scope) - 1; cursor > max {
+		cursor = max
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	l.cursor = cursor
+
+	l.start = cursor - offset
+	if max := len(l.scope) - l.size; l.start > max {
+		l.start = max
+	}
+	if l.start < 0 {
+		l.start = 0
+	}
+
+	l.settle(1)
+}
@@ -3,6 +3,7 @@ package list
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -162,3 +163,386 @@ func castList(list []interface{}) []rune {
 	}
 	return result
 }
+
+func TestListJumpTo(t *testing.T) {
+	fruits := []string{"apple", "banana", "blueberry", "cherry", "Blackberry", "ébc", "éé"}
+
+	l, err := New(fruits, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	l.Labeler = func(item interface{}) string {
+		return item.(string)
+	}
+
+	tcs := []struct {
+		prefix string
+		found  bool
+		index  int
+	}{
+		{prefix: "b", found: true, index: 1},
+		{prefix: "bl", found: true, index: 2},
+		{prefix: "bla", found: true, index: 4},
+		{prefix: "c", found: true, index: 3},
+		{prefix: "bb", found: true, index: 4},
+		{prefix: "bbb", found: true, index: 1},
+		{prefix: "x", found: false, index: 1},
+		{prefix: "ax", found: false, index: 1},
+		{prefix: "é", found: true, index: 5},
+		{prefix: "éx", found: false, index: 5},
+		{prefix: "éé", found: true, index: 6},
+		{prefix: "ééé", found: true, index: 5},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("jump to %q", tc.prefix), func(t *testing.T) {
+			found := l.JumpTo(tc.prefix)
+			if found != tc.found {
+				t.Errorf("expected found to be %t, got %t", tc.found, found)
+			}
+
+			if idx := l.Index(); idx != tc.index {
+				t.Errorf("expected index to be %d, got %d", tc.index, idx)
+			}
+
+			if l.Start() > tc.index || l.Start()+2 <= tc.index {
+				t.Errorf("expected item %d to be visible from %d", tc.index, l.Start())
+			}
+		})
+	}
+}
+
+func TestListIsSelectable(t *testing.T) {
+	letters := []rune{'-', 'a', 'b', '-', '-', 'c', 'd', 'e', '-'}
+
+	l, err := New(letters, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	l.IsSelectable = func(item interface{}) bool {
+		return item.(rune) != '-'
+	}
+
+	l.SetCursor(0)
+
+	tcs := []struct {
+		expect   []rune
+		move     string
+		selected rune
+	}{
+		{move: "prev", selected: 'a', expect: []rune{'-', 'a', 'b'}},
+		{move: "next", selected: 'b', expect: []rune{'-', 'a', 'b'}},
+		{move: "next", selected: 'c', expect: []rune{'-', '-', 'c'}},
+		{move: "prev", selected: 'b', expect: []rune{'b', '-', '-'}},
+		{move: "prev", selected: 'a', expect: []rune{'a', 'b', '-'}},
+		{move: "prev", selected: 'a', expect: []rune{'-', 'a', 'b'}},
+		{move: "down", selected: 'c', expect: []rune{'-', '-', 'c'}},
+		{move: "down", selected: 'd', expect: []rune{'d', 'e', '-'}},
+		{move: "next", selected: 'e', expect: []rune{'d', 'e', '-'}},
+		{move: "up", selected: 'c', expect: []rune{'-', '-', 'c'}},
+		{move: "up", selected: 'a', expect: []rune{'-', 'a', 'b'}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("list %s", tc.move), func(t *testing.T) {
+			switch tc.move {
+			case "next":
+				l.Next()
+			case "prev":
+				l.Prev()
+			case "up":
+				l.PageUp()
+			case "down":
+				l.PageDown()
+			default:
+				t.Fatalf("unknown move %q", tc.move)
+			}
+
+			list, idx := l.Items()
+
+			got := castList(list)
+
+			if !reflect.DeepEqual(tc.expect, got) {
+				t.Errorf("expected %q, got %q", tc.expect, got)
+			}
+
+			selected := list[idx]
+
+			if tc.selected != selected {
+				t.Errorf("expected selected to be %q, got %q", tc.selected, selected)
+			}
+
+			if !l.CanSelect() {
+				t.Errorf("expected selected item to be selectable")
+			}
+		})
+	}
+
+	t.Run("when no item is selectable", func(t *testing.T) {
+		l.IsSelectable = func(item interface{}) bool {
+			return false
+		}
+
+		l.SetCursor(2)
+		if l.CanSelect() {
+			t.Errorf("expected selected item not to be selectable")
+		}
+	})
+}
+
+func TestListSetIndex(t *testing.T) {
+	fruits := []string{"apple", "banana", "blueberry", "cherry"}
+
+	l, err := New(fruits, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	l.Searcher = func(input string, index int) bool {
+		return strings.HasPrefix(fruits[index], input)
+	}
+
+	if !l.SetIndex(3) || l.Index() != 3 {
+		t.Errorf("expected index to be 3, got %d", l.Index())
+	}
+
+	l.Search("b")
+
+	if !l.SetIndex(2) || l.Index() != 2 {
+		t.Errorf("expected index to be 2, got %d", l.Index())
+	}
+
+	if l.SetIndex(0) {
+		t.Errorf("expected filtered out item not to be found")
+	}
+
+	if l.SetIndex(4) {
+		t.Errorf("expected out of bounds index not to be found")
+	}
+}
+
+func TestListChanges(t *testing.T) {
+	fruits := []string{"apple", "banana", "blueberry", "cherry", "date"}
+
+	l, err := New(fruits, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	l.SetCursor(3)
+
+	selected := func() interface{} {
+		items, idx := l.Items()
+		if idx == NotFound {
+			return nil
+		}
+		return items[idx]
+	}
+
+	tcs := []struct {
+		name     string
+		change   func() error
+		selected interface{}
+		index    int
+		visible  []interface{}
+	}{
+		{
+			name:     "when inserting items above the cursor",
+			change:   func() error { return l.Insert(0, "apricot", "avocado") },
+			selected: "cherry", index: 5, visible: []interface{}{"blueberry", "cherry"},
+		},
+		{
+			name:     "when removing an item above the cursor",
+			change:   func() error { return l.Remove(1) },
+			selected: "cherry", index: 4, visible: []interface{}{"blueberry", "cherry"},
+		},
+		{
+			name:     "when updating the selected item",
+			change:   func() error { return l.Update(4, "cranberry") },
+			selected: "cranberry", index: 4, visible: []interface{}{"blueberry", "cranberry"},
+		},
+		{
+			name:     "when removing the selected item",
+			change:   func() error { return l.Remove(4) },
+			selected: "date", index: 4, visible: []interface{}{"blueberry", "date"},
+		},
+		{
+			name:     "when replacing the items",
+			change:   func() error { return l.Replace([]string{"date", "fig"}) },
+			selected: "date", index: 0, visible: []interface{}{"date", "fig"},
+		},
+		{
+			name:     "when removing all the items",
+			change:   func() error { return l.Replace([]string{}) },
+			selected: nil, index: NotFound,
+		},
+		{
+			name:     "when inserting into an empty list",
+			change:   func() error { return l.Insert(0, "grape") },
+			selected: "grape", index: 0, visible: []interface{}{"grape"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.change()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if s := selected(); s != tc.selected || l.Index() != tc.index {
+				t.Errorf("expected %v at %d to be selected, got %v at %d", tc.selected, tc.index, s, l.Index())
+			}
+
+			if items, _ := l.Items(); !reflect.DeepEqual(items, tc.visible) {
+				t.Errorf("expected visible items %v, got %v", tc.visible, items)
+			}
+		})
+	}
+
+	t.Run("when changing the items of a search", func(t *testing.T) {
+		fruits := []string{"apple", "banana", "blueberry", "cherry"}
+		l.Replace(fruits)
+
+		l.Searcher = func(input string, index int) bool {
+			return strings.HasPrefix(fruits[index], input)
+		}
+		l.Search("b")
+		l.Next()
+
+		fruits = append([]string{"blackberry"}, fruits...)
+		l.Insert(0, "blackberry")
+		if items, idx := l.Items(); !reflect.DeepEqual(items, []interface{}{"banana", "blueberry"}) || idx != 1 {
+			t.Errorf("expected blueberry to stay selected, got %v at %d", items, idx)
+		}
+	})
+
+	t.Run("when changing items out of the list", func(t *testing.T) {
+		if l.Insert(-1, "x") == nil || l.Remove(l.Len()) == nil || l.Update(l.Len(), "x") == nil {
+			t.Errorf("expected out of bounds changes to fail")
+		}
+
+		if l.Replace("x") == nil {
+			t.Errorf("expected items that are not a slice to fail")
+		}
+	})
+}
+
+func TestListMove(t *testing.T) {
+	letters := []rune{'a', 'b', 'c', 'd', 'e'}
+
+	l, err := New(letters, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tcs := []struct {
+		move   string
+		moved  bool
+		expect []rune
+		all    []rune
+	}{
+		{move: "up", moved: false, expect: []rune{'a', 'b'}, all: []rune{'a', 'b', 'c', 'd', 'e'}},
+		{move: "down", moved: true, expect: []rune{'b', 'a'}, all: []rune{'b', 'a', 'c', 'd', 'e'}},
+		{move: "down", moved: true, expect: []rune{'c', 'a'}, all: []rune{'b', 'c', 'a', 'd', 'e'}},
+		{move: "down", moved: true, expect: []rune{'d', 'a'}, all: []rune{'b', 'c', 'd', 'a', 'e'}},
+		{move: "down", moved: true, expect: []rune{'e', 'a'}, all: []rune{'b', 'c', 'd', 'e', 'a'}},
+		{move: "down", moved: false, expect: []rune{'e', 'a'}, all: []rune{'b', 'c', 'd', 'e', 'a'}},
+		{move: "up", moved: true, expect: []rune{'a', 'e'}, all: []rune{'b', 'c', 'd', 'a', 'e'}},
+		{move: "up", moved: true, expect: []rune{'a', 'd'}, all: []rune{'b', 'c', 'a', 'd', 'e'}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("move %s", tc.move), func(t *testing.T) {
+			var moved bool
+			if tc.move == "up" {
+				moved = l.MoveUp()
+			} else {
+				moved = l.MoveDown()
+			}
+
+			if moved != tc.moved {
+				t.Errorf("expected moved to be %t, got %t", tc.moved, moved)
+			}
+
+			list, idx := l.Items()
+			if got := castList(list); !reflect.DeepEqual(tc.expect, got) {
+				t.Errorf("expected visible items %q, got %q", tc.expect, got)
+			}
+
+			if list[idx] != 'a' {
+				t.Errorf("expected the moved item to stay selected, got %q", list[idx])
+			}
+
+			if got := castList(l.Values()); !reflect.DeepEqual(tc.all, got) {
+				t.Errorf("expected items %q, got %q", tc.all, got)
+			}
+		})
+	}
+
+	t.Run("when searching", func(t *testing.T) {
+		l.Searcher = func(input string, index int) bool {
+			return true
+		}
+		l.Search("a")
+
+		if l.MoveUp() {
+			t.Errorf("expected searched list not to move")
+		}
+	})
+}
+
+func TestListSort(t *testing.T) {
+	letters := []rune{'c', 'a', 'e', 'b', 'd'}
+
+	l, err := New(letters, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	l.Searcher = func(input string, index int) bool {
+		return letters[index] != rune(input[0])
+	}
+
+	l.SetCursor(2)
+	l.Sort(func(a, b interface{}) bool {
+		return a.(rune) < b.(rune)
+	})
+
+	list, idx := l.Items()
+	if got := castList(list); !reflect.DeepEqual([]rune{'c', 'd', 'e'}, got) || list[idx] != 'e' {
+		t.Errorf("expected the sorted items to keep e selected, got %q with %d", got, idx)
+	}
+
+	if l.Index() != 2 {
+		t.Errorf("expected the original index 2 of e, got %d", l.Index())
+	}
+
+	if got := castList(l.Values()); !reflect.DeepEqual(letters, got) {
+		t.Errorf("expected the values to keep their order, got %q", got)
+	}
+
+	if l.MoveUp() {
+		t.Errorf("expected a sorted list not to move")
+	}
+
+	l.Search("b")
+	list, _ = l.Items()
+	if got := castList(list); !reflect.DeepEqual([]rune{'a', 'c', 'd'}, got) {
+		t.Errorf("expected the searched items to be sorted, got %q", got)
+	}
+
+	l.CancelSearch()
+	list, _ = l.Items()
+	if got := castList(list); !reflect.DeepEqual([]rune{'a', 'b', 'c'}, got) {
+		t.Errorf("expected the items to stay sorted once the search ends, got %q", got)
+	}
+
+	l.SetIndex(3)
+	l.Sort(nil)
+	list, idx = l.Items()
+	if got := castList(list); !reflect.DeepEqual([]rune{'e', 'b', 'd'}, got) || list[idx] != 'b' {
+		t.Errorf("expected the original order to keep b selected, got %q with %d", got, idx)
+	}
+}
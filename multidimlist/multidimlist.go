@@ -3,7 +3,6 @@ package multidimlist
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 )
 
@@ -12,10 +11,35 @@ import (
 // the item fits the searched term.
 type Searcher func(input string, item interface{}, index int) bool
 
+// ChildrenLoader is a function signature used to load the nested items of an item the first time the
+// cursor dives into it. It receives the index of the item across dimensions, like List.Index, and the item.
+// Returning nil nested items tells the item is not a branch.
+type ChildrenLoader func(path []int, item interface{}) ([]interface{}, error)
+
+// LoadError is returned by DiveIn when the nested items of an item could not be loaded.
+type LoadError struct {
+	// Path is the index of the item across dimensions.
+	Path []int
+	// Err is the error returned by the loader.
+	Err error
+}
+
+// Error returns the error of the loader.
+func (e *LoadError) Error() string {
+	return e.Err.Error()
+}
+
 // NotFound is an index returned when no item was selected. This could
 // happen due to a search without results.
 const NotFound = -1
 
+// frame holds the state of the cursor inside a layer of the list.
+type frame struct {
+	cursor int
+	start  int
+	term   string
+}
+
 // List holds a collection of items that can be displayed with an N number of
 // visible items. The list can be moved up, down by one item of time or an
 // entire page (ie: visible size). It keeps track of the current selected item.
@@ -31,6 +55,21 @@ type List struct {
 	cursor []int
 	// Searcher is the function used for filtering items
 	Searcher Searcher
+	// DeepSearch sets whether searching walks through all the layers of the list instead of the current one.
+	// The items that are not branches and match the searched term are listed as Match values.
+	DeepSearch bool
+	// LoadChildren is the function used to load the nested items of items that are not branches. The loaded
+	// items are kept for the lifetime of the list.
+	LoadChildren ChildrenLoader
+	// loaded holds the nested items loaded by their index across dimensions
+	loaded map[string][]interface{}
+
+	// term is the searched term of the current layer
+	term string
+	// frames holds the state of the layers the cursor dived out of, from the root layer
+	frames []frame
+	// visited holds the state the layers were left in by the index of their item across dimensions
+	visited map[string]frame
 
 	// size is the number of visible options
 	size int
@@ -38,24 +77,21 @@ type List struct {
 	start int
 }
 
-// New creates and initializes a list of searchable items. The items attribute must be a slice type with a
-// size greater than 0. Error will be returned if those two conditions are not met.
+// New creates and initializes a list of searchable items. The items attribute must be a branch, as told by
+// IsBranch, and the size must be greater than 0. Error will be returned if those two conditions are not met.
+//
+// Items are either nested slices, maps of labels to their nested items or values implementing Node.
 func New(items interface{}, size int) (*List, error) {
 	if size < 1 {
 		return nil, fmt.Errorf("list size %d must be greater than 0", size)
 	}
 
-	if items == nil || reflect.TypeOf(items).Kind() != reflect.Slice {
-		return nil, fmt.Errorf("items %v is not a slice", items)
+	nested, ok := children(items)
+	if !ok {
+		return nil, fmt.Errorf("items %v is not a branch", items)
 	}
 
-	slice := reflect.ValueOf(items)
-	values := make([]*interface{}, slice.Len())
-
-	for i := range values {
-		item := slice.Index(i).Interface()
-		values[i] = &item
-	}
+	values := pointers(nested)
 
 	return &List{size: size, originalItem: items, items: values, scope: values, cursor: []int{0}}, nil
 }
@@ -79,20 +115,33 @@ func (l *List) Search(term string) {
 	term = strings.Trim(term, " ")
 	l.cursor[len(l.cursor)-1] = 0
 	l.start = 0
+	l.term = term
+	l.scope = l.items
 	l.search(term)
 }
 
+// Term returns the searched term of the current layer, which is empty when there is no search.
+func (l *List) Term() string {
+	return l.term
+}
+
 // CancelSearch stops the current search and returns the list to its
 // original order.
 func (l *List) CancelSearch() error {
 	l.cursor[len(l.cursor)-1] = 0
 	l.start = 0
+	l.term = ""
 	l.scope = l.items
 
 	return nil
 }
 
 func (l *List) search(term string) {
+	if l.DeepSearch {
+		l.scope = pointers(l.deepSearch(term))
+		return
+	}
+
 	var scope []*interface{}
 	for i, item := range l.scope {
 		if item != nil {
@@ -105,6 +154,32 @@ func (l *List) search(term string) {
 	l.scope = scope
 }
 
+// deepSearch returns the items of all the layers that are not branches and match the given term.
+func (l *List) deepSearch(term string) []interface{} {
+	var matches []interface{}
+
+	var walk func(items []interface{}, path []int, ancestors []interface{})
+	walk = func(items []interface{}, path []int, ancestors []interface{}) {
+		for i, item := range items {
+			index := append(append([]int{}, path...), i)
+
+			if nested, ok := l.nested(index, item); ok {
+				walk(nested, index, append(append([]interface{}{}, ancestors...), item))
+				continue
+			}
+
+			if l.Searcher(term, item, i) {
+				matches = append(matches, &Match{Item: item, Path: index, Ancestors: ancestors})
+			}
+		}
+	}
+
+	root, _ := children(l.originalItem)
+	walk(root, nil, nil)
+
+	return matches
+}
+
 // Start returns the current render start position of the list.
 func (l *List) Start() int {
 	return l.start
@@ -162,16 +237,38 @@ func (l *List) Next() {
 	}
 }
 
-// DiveIn moves the cursor to the next layer of the list.
+// DiveIn moves the cursor to the next layer of the list. Diving into a match of a deep search first moves the
+// cursor to the matching item inside its own layer, ending the search.
 func (l *List) DiveIn() error {
+	if len(l.scope) == 0 {
+		return fmt.Errorf("no item is selected")
+	}
+
 	// check is selected item could be dived into
+	err := l.Load()
+	if err != nil {
+		return err
+	}
+
 	selected := l.scope[l.cursor[len(l.cursor)-1]]
-	selectedValue := reflect.ValueOf(*selected)
+	item := unwrap(*selected)
+	path := l.Index()
 
-	if selectedValue.Kind() != reflect.Slice {
+	nested, ok := l.nested(path, item)
+	if !ok {
 		return fmt.Errorf("selected item is not a list")
 	}
 
+	if match, ok := (*selected).(*Match); ok {
+		err := l.reveal(match)
+		if err != nil {
+			return err
+		}
+		selected = l.scope[l.cursor[len(l.cursor)-1]]
+	}
+
+	l.frames = append(l.frames, l.frame())
+
 	// find actual cursor index
 	for i, item := range l.items {
 		if item == selected {
@@ -183,14 +280,54 @@ func (l *List) DiveIn() error {
 	// append 0 to cursor
 	l.cursor = append(l.cursor, 0)
 
-	// reset items and scope
-	values := make([]*interface{}, selectedValue.Len())
-	for i := range values {
-		item := selectedValue.Index(i).Interface()
-		values[i] = &item
+	// reset items and scope to the state the layer was left in
+	l.items = pointers(nested)
+	l.restore(l.visited[fmt.Sprint(path)])
+
+	return nil
+}
+
+// Load loads the nested items of the selected item with LoadChildren when it needs loading, without diving
+// into it. A LoadError is returned when the loading fails.
+func (l *List) Load() error {
+	if !l.NeedsLoading() {
+		return nil
 	}
-	l.scope = values
-	l.items = values
+
+	selected := l.scope[l.cursor[len(l.cursor)-1]]
+	path := l.Index()
+
+	values, err := l.LoadChildren(path, unwrap(*selected))
+	if err != nil {
+		return &LoadError{Path: path, Err: err}
+	}
+
+	if l.loaded == nil {
+		l.loaded = map[string][]interface{}{}
+	}
+	l.loaded[fmt.Sprint(path)] = values
+
+	return nil
+}
+
+// reveal moves the cursor to the item of the match inside its own layer, the layers above keeping their cursor
+// on its ancestors.
+func (l *List) reveal(match *Match) error {
+	parents := match.Path[:len(match.Path)-1]
+
+	nested, err := l.layer(parents)
+	if err != nil {
+		return err
+	}
+
+	l.frames = nil
+	for _, c := range parents {
+		l.frames = append(l.frames, frame{cursor: c, start: c})
+	}
+
+	l.cursor = append([]int{}, match.Path...)
+	l.items = pointers(nested)
+	l.restore(frame{cursor: match.Path[len(match.Path)-1]})
 
 	return nil
 }
@@ -203,39 +340,229 @@ func (l *List) DiveOut() error {
 	}
 
 	// run through the cursor to find the previous items
-	if l.originalItem == nil || reflect.TypeOf(l.originalItem).Kind() != reflect.Slice {
-		return fmt.Errorf("items %v is not a slice", l.originalItem)
+	nested, err := l.layer(l.cursor[:len(l.cursor)-2])
+	if err != nil {
+		return err
 	}
 
-	// get the original slice
-	slice := reflect.ValueOf(l.originalItem).Convert(reflect.TypeOf(l.originalItem))
+	if l.visited == nil {
+		l.visited = map[string]frame{}
+	}
+	l.visited[fmt.Sprint(l.cursor[:len(l.cursor)-1])] = l.frame()
 
-	// find the actual cursor index
-	for i, c := range l.cursor {
-		if len(l.cursor)-2 <= i {
-			break
+	// pop cursor index and reset items and scope to the state the layer was left in
+	parent := frame{}
+	if len(l.frames) > 0 {
+		parent = l.frames[len(l.frames)-1]
+		l.frames = l.frames[:len(l.frames)-1]
+	}
+
+	l.cursor = l.cursor[:len(l.cursor)-1]
+	l.items = pointers(nested)
+	l.restore(parent)
+
+	return nil
+}
+
+// layer returns the items of the layer reached by diving into the items at the given indices.
+func (l *List) layer(path []int) ([]interface{}, error) {
+	nested, ok := children(l.originalItem)
+	if !ok {
+		return nil, fmt.Errorf("items %v is not a branch", l.originalItem)
+	}
+
+	for i, c := range path {
+		if c < 0 || c >= len(nested) {
+			return nil, fmt.Errorf("cursor %v is out of the items", path)
 		}
 
-		slice = slice.Index(c).Elem()
-		if reflect.TypeOf(slice.Interface()).Kind() != reflect.Slice {
-			return fmt.Errorf("items %v is not a slice", slice)
+		item := nested[c]
+		nested, ok = l.nested(path[:i+1], item)
+		if !ok {
+			return nil, fmt.Errorf("items %v is not a branch", item)
 		}
 	}
 
-	// pop cursor index and reset items and scope
-	values := make([]*interface{}, slice.Len())
-	for i := range values {
-		item := slice.Index(i).Interface()
-		values[i] = &item
+	return nested, nil
+}
+
+// Parent returns the items of the layer the cursor dived out of and the index of the item it dived into.
+// The NotFound index is returned at the root layer.
+func (l *List) Parent() ([]interface{}, int) {
+	if len(l.cursor) == 1 {
+		return nil, NotFound
+	}
+
+	items, err := l.layer(l.cursor[:len(l.cursor)-2])
+	if err != nil {
+		return nil, NotFound
 	}
 
-	l.cursor = l.cursor[:len(l.cursor)-1]
-	l.items = values
-	l.scope = values
+	return items, l.cursor[len(l.cursor)-2]
+}
+
+// Preview returns the nested items of the selected item, without loading them. Nil is returned if the
+// selected item is not a branch.
+func (l *List) Preview() []interface{} {
+	if len(l.scope) == 0 {
+		return nil
+	}
+
+	selected := l.scope[l.cursor[len(l.cursor)-1]]
+	nested, _ := l.nested(l.Index(), unwrap(*selected))
+
+	return nested
+}
+
+// frame returns the state of the cursor inside the current layer.
+func (l *List) frame() frame {
+	return frame{cursor: l.cursor[len(l.cursor)-1], start: l.start, term: l.term}
+}
+
+// restore searches the current layer and moves the cursor as they were in the given state.
+func (l *List) restore(f frame) {
+	l.scope = l.items
+	l.term = ""
+	if f.term != "" && l.Searcher != nil {
+		l.term = f.term
+		l.search(f.term)
+	}
+
+	l.cursor[len(l.cursor)-1] = 0
+	l.start = 0
+	l.SetCursor(f.cursor)
+	l.SetStart(f.start)
+}
+
+// DiveOutTo moves the cursor back to the given layer of the list, 0 being the root layer.
+func (l *List) DiveOutTo(depth int) error {
+	if depth < 0 || depth >= len(l.cursor) {
+		return fmt.Errorf("cursor is not below layer %d", depth)
+	}
+
+	for len(l.cursor) > depth+1 {
+		err := l.DiveOut()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Ancestors returns the items the cursor dived into, starting from the root layer of the list.
+func (l *List) Ancestors() []interface{} {
+	var result []interface{}
+
+	items, _ := children(l.originalItem)
+	for i, c := range l.cursor[:len(l.cursor)-1] {
+		if c < 0 || c >= len(items) {
+			break
+		}
+
+		result = append(result, items[c])
+		items, _ = l.nested(l.cursor[:i+1], items[c])
+	}
+
+	return result
+}
+
+// NeedsLoading returns whether diving into the selected item first loads its nested items.
+func (l *List) NeedsLoading() bool {
+	if l.LoadChildren == nil || len(l.scope) == 0 {
+		return false
+	}
+
+	selected := l.scope[l.cursor[len(l.cursor)-1]]
+	if IsBranch(unwrap(*selected)) {
+		return false
+	}
+
+	_, ok := l.loaded[fmt.Sprint(l.Index())]
+	return !ok
+}
+
+// IsBranchAt returns whether the item at the given index across dimensions is a branch. Unlike IsBranch, it
+// tells the loaded items apart, the items still to be loaded by LoadChildren being branches until their
+// loading returns no nested items.
+func (l *List) IsBranchAt(index []int, item interface{}) bool {
+	item = unwrap(item)
+	if _, ok := l.nested(index, item); ok {
+		return true
+	}
+
+	if l.LoadChildren == nil {
+		return false
+	}
+
+	_, loaded := l.loaded[fmt.Sprint(index)]
+	return !loaded
+}
+
+// nested returns the nested items of the item at the given index across dimensions, including the loaded
+// and replaced ones.
+func (l *List) nested(path []int, item interface{}) ([]interface{}, bool) {
+	if values := l.loaded[fmt.Sprint(path)]; values != nil {
+		return values, true
+	}
+
+	return children(item)
+}
+
+// Replace replaces the items of the current layer with the given ones, which must be a branch as told by
+// IsBranch. The search of the layer is applied again and the cursor keeps its position when possible. The
+// state of the layers below the current one is forgotten.
+func (l *List) Replace(items interface{}) error {
+	values, ok := children(items)
+	if !ok {
+		return fmt.Errorf("items %v is not a branch", items)
+	}
+
+	path := l.cursor[:len(l.cursor)-1]
+	if len(path) == 0 {
+		l.originalItem = items
+		l.loaded = nil
+		l.visited = nil
+	} else {
+		if values == nil {
+			values = []interface{}{}
+		}
+
+		l.forget(path)
+		l.loaded[fmt.Sprint(path)] = values
+	}
+
+	f := l.frame()
+	l.items = pointers(values)
+	l.restore(f)
 
 	return nil
 }
 
+// forget drops the loaded items and the state of the layers below the item at the given index.
+func (l *List) forget(path []int) {
+	if l.loaded == nil {
+		l.loaded = map[string][]interface{}{}
+	}
+
+	prefix := strings.TrimSuffix(fmt.Sprint(path), "]")
+	below := func(key string) bool {
+		return key == prefix+"]" || strings.HasPrefix(key, prefix+" ")
+	}
+
+	for key := range l.loaded {
+		if below(key) {
+			delete(l.loaded, key)
+		}
+	}
+
+	for key := range l.visited {
+		if below(key) {
+			delete(l.visited, key)
+		}
+	}
+}
+
 // PageUp moves the visible list backward by x items. Where x is the size of the
 // visible items on the list. The selected item becomes the first visible item.
 // If the list is already at the bottom, the selected item becomes the last
@@ -294,7 +621,24 @@ func (l *List) CanPageUp() bool {
 // Index returns the index of the item currently selected inside the searched list. If no item is selected,
 // the NotFound (-1) index is returned.
 func (l *List) Index() []int {
-	selected := l.scope[l.cursor[len(l.cursor)-1]]
+	return l.index(l.scope[l.cursor[len(l.cursor)-1]])
+}
+
+// ItemIndex returns the index across dimensions of the visible item at the given position, as returned by
+// Items. The NotFound index is returned for positions out of the visible items.
+func (l *List) ItemIndex(i int) []int {
+	i += l.start
+	if i < l.start || i >= len(l.scope) || i >= l.start+l.size {
+		return []int{NotFound}
+	}
+
+	return l.index(l.scope[i])
+}
+
+func (l *List) index(selected *interface{}) []int {
+	if match, ok := (*selected).(*Match); ok {
+		return append([]int{}, match.Path...)
+	}
 
 	rt := []int{}
 	for _, c := range l.cursor {
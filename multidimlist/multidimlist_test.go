@@ -1,6 +1,8 @@
 package multidimlist
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -428,3 +430,373 @@ func TestMultiLayerListNavigation(t *testing.T) {
 		t.Errorf("After cancel search, got %d items, want 2", len(items))
 	}
 }
+
+func TestList_Ancestors(t *testing.T) {
+	testData := []interface{}{
+		"1",
+		[]interface{}{
+			"2.1",
+			[]interface{}{"2.2.1", "2.2.2"},
+		},
+	}
+
+	list, _ := New(testData, 2)
+	if ancestors := list.Ancestors(); len(ancestors) != 0 {
+		t.Errorf("Ancestors() = %v, want none at the root", ancestors)
+	}
+
+	list.Next()
+	list.DiveIn()
+	list.Next()
+	list.DiveIn()
+
+	ancestors := list.Ancestors()
+	if !reflect.DeepEqual(ancestors, []interface{}{testData[1], testData[1].([]interface{})[1]}) {
+		t.Errorf("Ancestors() = %v, want both dived items", ancestors)
+	}
+}
+
+func TestList_DiveOutTo(t *testing.T) {
+	testData := []interface{}{
+		"1",
+		[]interface{}{
+			"2.1",
+			[]interface{}{"2.2.1", "2.2.2"},
+		},
+	}
+
+	list, _ := New(testData, 2)
+	list.Next()
+	list.DiveIn()
+	list.Next()
+	list.DiveIn()
+
+	if err := list.DiveOutTo(3); err == nil {
+		t.Errorf("DiveOutTo(3) error = nil, want error below the current layer")
+	}
+
+	if err := list.DiveOutTo(0); err != nil {
+		t.Fatalf("DiveOutTo(0) error = %v", err)
+	}
+
+	if !reflect.DeepEqual(list.Index(), []int{1}) {
+		t.Errorf("DiveOutTo(0) index = %v, want [1]", list.Index())
+	}
+}
+
+func TestList_LoadChildren(t *testing.T) {
+	calls := map[string]int{}
+
+	list, _ := New([]string{"account", "broken", "leaf"}, 3)
+	list.LoadChildren = func(path []int, item interface{}) ([]interface{}, error) {
+		calls[item.(string)]++
+
+		switch item {
+		case "account":
+			return []interface{}{"project", []interface{}{"instance"}}, nil
+		case "broken":
+			return nil, errors.New("timeout")
+		}
+		return nil, nil
+	}
+
+	if !list.NeedsLoading() {
+		t.Fatalf("NeedsLoading() = false, want true before the first dive")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := list.DiveIn(); err != nil {
+			t.Fatalf("DiveIn() error = %v", err)
+		}
+
+		list.Next()
+		list.DiveIn()
+
+		if err := list.DiveOutTo(0); err != nil {
+			t.Fatalf("DiveOutTo(0) error = %v", err)
+		}
+	}
+
+	if calls["account"] != 1 || list.NeedsLoading() {
+		t.Errorf("LoadChildren() called %d times, want loaded items to be kept", calls["account"])
+	}
+
+	list.Next()
+	err := list.DiveIn()
+	if loadErr, ok := err.(*LoadError); !ok || loadErr.Error() != "timeout" || !reflect.DeepEqual(loadErr.Path, []int{1}) {
+		t.Errorf("DiveIn() error = %v, want load error", err)
+	}
+
+	if !list.NeedsLoading() {
+		t.Errorf("NeedsLoading() = false, want failed loads to be retried")
+	}
+
+	list.Next()
+	if err := list.DiveIn(); err == nil || list.NeedsLoading() {
+		t.Errorf("DiveIn() into a leaf error = %v, want error and no more loading", err)
+	}
+}
+
+func TestList_Frames(t *testing.T) {
+	children := []interface{}{"c0", "c1", "c2", "c3", "c4", "c5"}
+	testData := []interface{}{"a", "b", "c", "d", "e", "f", "g", children, children}
+
+	list, _ := New(testData, 3)
+	list.Searcher = func(input string, item interface{}, index int) bool {
+		s, ok := item.(string)
+		return !ok || strings.Contains(s, input)
+	}
+
+	list.SetCursor(7)
+	list.SetStart(6)
+	list.DiveIn()
+
+	if list.cursor[1] != 0 || list.start != 0 {
+		t.Fatalf("DiveIn() cursor = %v, start = %v, want a new layer at the top", list.cursor, list.start)
+	}
+
+	list.SetCursor(4)
+	list.DiveOut()
+
+	if list.cursor[0] != 7 || list.start != 6 {
+		t.Errorf("DiveOut() cursor = %v, start = %v, want cursor = 7, start = 6", list.cursor, list.start)
+	}
+
+	list.DiveIn()
+	if list.cursor[1] != 4 || list.start != 2 {
+		t.Errorf("DiveIn() cursor = %v, start = %v, want cursor = 4, start = 2", list.cursor, list.start)
+	}
+
+	list.DiveOut()
+	list.Next()
+	list.DiveIn()
+	if list.cursor[1] != 0 || list.start != 0 {
+		t.Errorf("DiveIn() into another item cursor = %v, start = %v, want a new layer at the top", list.cursor, list.start)
+	}
+
+	list.DiveOut()
+	list.Search("g")
+	list.Next()
+	list.DiveIn()
+	list.Search("3")
+
+	if !reflect.DeepEqual(list.Index(), []int{7, 3}) || list.Term() != "3" {
+		t.Fatalf("Search() inside a layer index = %v, want [7 3]", list.Index())
+	}
+
+	list.DiveOut()
+	items, active := list.Items()
+	if list.Term() != "g" || len(items) != 3 || active != 1 || !reflect.DeepEqual(list.Index(), []int{7}) {
+		t.Errorf("DiveOut() = %v, %v with term %q, want the search of the layer", items, active, list.Term())
+	}
+
+	list.DiveIn()
+	if list.Term() != "3" || !reflect.DeepEqual(list.Index(), []int{7, 3}) {
+		t.Errorf("DiveIn() index = %v with term %q, want the search of the layer", list.Index(), list.Term())
+	}
+}
+
+func TestList_DeepSearch(t *testing.T) {
+	testData := []interface{}{
+		"apple",
+		map[string]interface{}{
+			"fruits":  []interface{}{"grape", "apricot"},
+			"flowers": []interface{}{"aster"},
+		},
+	}
+
+	list, _ := New(testData, 5)
+	list.DeepSearch = true
+	list.Searcher = func(input string, item interface{}, index int) bool {
+		return strings.HasPrefix(item.(string), input)
+	}
+
+	list.Search("ap")
+	items, _ := list.Items()
+
+	var labels []interface{}
+	for _, item := range items {
+		labels = append(labels, Label(item))
+	}
+
+	if !reflect.DeepEqual(labels, []interface{}{"apple", "flowers & fruits › fruits › apricot"}) {
+		t.Errorf("Search() = %v, want the matches of all layers", labels)
+	}
+
+	list.Next()
+	if !reflect.DeepEqual(list.Index(), []int{1, 1, 1}) {
+		t.Errorf("Index() = %v, want the full index of the match", list.Index())
+	}
+
+	list.Search("a")
+	if items, _ := list.Items(); len(items) != 3 {
+		t.Errorf("Search() = %v, want a new search instead of narrowing the previous one", items)
+	}
+}
+
+func TestList_DeepSearchDiveIn(t *testing.T) {
+	accounts := []interface{}{"acct-a", "acct-b"}
+
+	var loadedPath []int
+	var loadedItem interface{}
+
+	list, _ := New([]interface{}{accounts, "other"}, 5)
+	list.DeepSearch = true
+	list.Searcher = func(input string, item interface{}, index int) bool {
+		return strings.Contains(fmt.Sprint(item), input)
+	}
+	list.LoadChildren = func(path []int, item interface{}) ([]interface{}, error) {
+		if item == "other" {
+			return nil, nil
+		}
+		loadedPath, loadedItem = path, item
+		return []interface{}{"project-1", "project-2"}, nil
+	}
+
+	list.Search("acct-b")
+	if !list.NeedsLoading() {
+		t.Fatalf("NeedsLoading() = false, want the match to be loaded")
+	}
+
+	if err := list.DiveIn(); err != nil {
+		t.Fatalf("DiveIn() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(loadedPath, []int{0, 1}) || loadedItem != "acct-b" {
+		t.Errorf("LoadChildren() got %v, %v, want the item of the match", loadedPath, loadedItem)
+	}
+
+	if !reflect.DeepEqual(list.GetCursor(), []int{0, 1, 0}) || !reflect.DeepEqual(list.Index(), []int{0, 1, 0}) {
+		t.Errorf("DiveIn() cursor = %v, index = %v, want [0 1 0]", list.GetCursor(), list.Index())
+	}
+
+	if ancestors := list.Ancestors(); !reflect.DeepEqual(ancestors, []interface{}{accounts, "acct-b"}) {
+		t.Errorf("Ancestors() = %v, want the ancestors of the match", ancestors)
+	}
+
+	list.DiveOut()
+	if !reflect.DeepEqual(list.Index(), []int{0, 1}) || list.Term() != "" {
+		t.Errorf("DiveOut() index = %v with term %q, want the layer of the match", list.Index(), list.Term())
+	}
+
+	list.DiveOut()
+	if !reflect.DeepEqual(list.Index(), []int{0}) {
+		t.Errorf("DiveOut() index = %v, want the ancestor of the match", list.Index())
+	}
+
+	list.Search("other")
+	if err := list.DiveIn(); err == nil || !reflect.DeepEqual(list.Index(), []int{1}) || list.Term() != "other" {
+		t.Errorf("DiveIn() into a leaf match error = %v, index = %v, want an error and the search kept", err, list.Index())
+	}
+}
+
+func TestList_SearchAgain(t *testing.T) {
+	list, _ := New([]string{"apple", "banana", "cherry"}, 3)
+	list.Searcher = func(input string, item interface{}, index int) bool {
+		return strings.Contains(item.(string), input)
+	}
+
+	list.Search("an")
+	list.Search("a")
+
+	if items, _ := list.Items(); len(items) != 2 {
+		t.Errorf("Search() = %v, want [apple banana]", items)
+	}
+}
+
+func TestList_ParentAndPreview(t *testing.T) {
+	testData := []interface{}{
+		"1",
+		[]interface{}{"2.1", []interface{}{"2.2.1"}},
+	}
+
+	list, _ := New(testData, 2)
+	if items, idx := list.Parent(); items != nil || idx != NotFound {
+		t.Errorf("Parent() = %v, %v, want none at the root", items, idx)
+	}
+
+	if preview := list.Preview(); preview != nil {
+		t.Errorf("Preview() = %v, want none for a leaf", preview)
+	}
+
+	list.Next()
+	if preview := list.Preview(); !reflect.DeepEqual(preview, testData[1]) {
+		t.Errorf("Preview() = %v, want the nested items", preview)
+	}
+
+	list.DiveIn()
+	list.Next()
+
+	items, idx := list.Parent()
+	if !reflect.DeepEqual(items, testData) || idx != 1 {
+		t.Errorf("Parent() = %v, %v, want the root layer", items, idx)
+	}
+
+	if preview := list.Preview(); !reflect.DeepEqual(preview, []interface{}{"2.2.1"}) {
+		t.Errorf("Preview() = %v, want [2.2.1]", preview)
+	}
+}
+
+func TestList_ItemIndex(t *testing.T) {
+	testData := []interface{}{"a", []interface{}{"b.1", "b.2", "b.3"}}
+
+	list, _ := New(testData, 2)
+	list.Next()
+	list.DiveIn()
+	list.SetCursor(2)
+
+	if index := list.ItemIndex(0); !reflect.DeepEqual(index, []int{1, 1}) {
+		t.Errorf("ItemIndex(0) = %v, want [1 1]", index)
+	}
+
+	if index := list.ItemIndex(2); !reflect.DeepEqual(index, []int{NotFound}) {
+		t.Errorf("ItemIndex(2) = %v, want [%d]", index, NotFound)
+	}
+}
+
+func TestList_Replace(t *testing.T) {
+	testData := []interface{}{"a", []interface{}{"b.1", "b.2", []interface{}{"b.3.1"}}}
+
+	list, _ := New(testData, 2)
+	list.Searcher = func(input string, item interface{}, index int) bool {
+		s, ok := item.(string)
+		return ok && strings.Contains(s, input)
+	}
+
+	list.Next()
+	list.DiveIn()
+	list.SetCursor(2)
+	list.DiveIn()
+	list.DiveOut()
+	list.Search("b")
+	list.Next()
+
+	err := list.Replace([]string{"b.1", "b.2", "b.4", "c"})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	items, idx := list.Items()
+	if !reflect.DeepEqual(items, []interface{}{"b.1", "b.2"}) || idx != 1 || list.Term() != "b" {
+		t.Errorf("Replace() = %v, %v with term %q, want the search applied again", items, idx, list.Term())
+	}
+
+	list.DiveOut()
+	if preview := list.Preview(); !reflect.DeepEqual(preview, []interface{}{"b.1", "b.2", "b.4", "c"}) {
+		t.Errorf("Preview() = %v, want the replaced items", preview)
+	}
+
+	list.DiveIn()
+	if _, ok := list.visited["[1 2]"]; ok || !reflect.DeepEqual(list.Index(), []int{1, 1}) {
+		t.Errorf("DiveIn() index = %v with %v visited, want the replaced layer", list.Index(), list.visited)
+	}
+
+	list.DiveOut()
+	if err := list.Replace([]string{"x"}); err != nil || !reflect.DeepEqual(list.Index(), []int{0}) {
+		t.Errorf("Replace() at the root = %v, %v, want [0]", list.Index(), err)
+	}
+
+	if err := list.Replace("x"); err == nil {
+		t.Errorf("Replace() with a leaf error = nil, want error")
+	}
+}
@@ -0,0 +1,153 @@
+package multidimlist
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Node is an item holding nested items under a label of its own, like a region holding its clusters.
+type Node interface {
+	// Label returns the value displaying the node.
+	Label() interface{}
+	// Children returns the nested items of the node. Nodes returning nil are leaves.
+	Children() []interface{}
+}
+
+// Entry is an entry of a map given as items. Its key labels it and its value holds its nested items, unless
+// the value is not a branch in which case the entry is a leaf.
+type Entry struct {
+	Key   string
+	Value interface{}
+}
+
+// Label returns the key of the entry.
+func (e Entry) Label() interface{} {
+	return e.Key
+}
+
+// Children returns the nested items of the value of the entry.
+func (e Entry) Children() []interface{} {
+	values, _ := children(e.Value)
+	return values
+}
+
+// String returns the key of the entry.
+func (e Entry) String() string {
+	return e.Key
+}
+
+// Match is an item found by a deep search along with its location.
+type Match struct {
+	// Item is the matching item.
+	Item interface{}
+	// Path is the index of the item across dimensions.
+	Path []int
+	// Ancestors are the branches holding the item, starting from the root layer.
+	Ancestors []interface{}
+}
+
+// Label returns the labels of the ancestors of the item followed by the label of the item, like
+// "a › b › match".
+func (m *Match) Label() interface{} {
+	labels := make([]string, 0, len(m.Ancestors)+1)
+	for _, item := range append(m.Ancestors, m.Item) {
+		labels = append(labels, labelString(item))
+	}
+	return strings.Join(labels, " › ")
+}
+
+// Children returns no nested items as matches are never branches.
+func (m *Match) Children() []interface{} {
+	return nil
+}
+
+// String returns the label of the match.
+func (m *Match) String() string {
+	return m.Label().(string)
+}
+
+// unwrap returns the item of the given match, or the given item itself if it is not a match.
+func unwrap(item interface{}) interface{} {
+	if match, ok := item.(*Match); ok {
+		return match.Item
+	}
+	return item
+}
+
+// labelString returns the label of the given item as a string. The labels of the nested items of branches
+// without labels of their own are joined together.
+func labelString(item interface{}) string {
+	_, isNode := item.(Node)
+	values, ok := children(item)
+	if isNode || !ok {
+		return fmt.Sprint(Label(item))
+	}
+
+	labels := make([]string, len(values))
+	for i, value := range values {
+		labels[i] = fmt.Sprint(Label(value))
+	}
+	return strings.Join(labels, " & ")
+}
+
+// Label returns the value displaying the given item, which is the item itself unless it is a Node.
+func Label(item interface{}) interface{} {
+	if node, ok := item.(Node); ok {
+		return node.Label()
+	}
+	return item
+}
+
+// IsBranch returns whether the item holds nested items. Slices, maps with string keys and nodes with
+// children are branches. The entries of a map are sorted by key.
+func IsBranch(item interface{}) bool {
+	_, ok := children(item)
+	return ok
+}
+
+// children returns the nested items of a branch and whether the item is a branch.
+func children(item interface{}) ([]interface{}, bool) {
+	if node, ok := item.(Node); ok {
+		values := node.Children()
+		return values, values != nil
+	}
+
+	if item == nil {
+		return nil, false
+	}
+
+	val := reflect.ValueOf(item)
+
+	switch {
+	case val.Kind() == reflect.Slice:
+		values := make([]interface{}, val.Len())
+		for i := range values {
+			values[i] = val.Index(i).Interface()
+		}
+		return values, true
+	case val.Kind() == reflect.Map && val.Type().Key().Kind() == reflect.String:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = Entry{Key: key.String(), Value: val.MapIndex(key).Interface()}
+		}
+		return values, true
+	}
+
+	return nil, false
+}
+
+// pointers returns pointers to the given items, used to tell items apart once filtered.
+func pointers(items []interface{}) []*interface{} {
+	values := make([]*interface{}, len(items))
+	for i := range items {
+		values[i] = &items[i]
+	}
+	return values
+}
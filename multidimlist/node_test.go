@@ -0,0 +1,88 @@
+package multidimlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+type region struct {
+	name     string
+	clusters []interface{}
+}
+
+func (r region) Label() interface{} {
+	return r.name
+}
+
+func (r region) Children() []interface{} {
+	return r.clusters
+}
+
+func TestNodes(t *testing.T) {
+	items := []interface{}{
+		region{name: "eu", clusters: []interface{}{
+			map[string]interface{}{"prod": []string{"web", "db"}, "dev": "single"},
+		}},
+		region{name: "us"},
+	}
+
+	if !IsBranch(items[0]) || IsBranch(items[1]) {
+		t.Errorf("IsBranch() should only be true for nodes with children")
+	}
+
+	if Label(items[0]) != "eu" || Label("plain") != "plain" {
+		t.Errorf("Label() = %v, want node label", Label(items[0]))
+	}
+
+	list, err := New(items, 3)
+	if err != nil {
+		t.Fatalf("Failed to create new list: %v", err)
+	}
+
+	if err := list.DiveIn(); err != nil {
+		t.Fatalf("DiveIn() into a node error = %v", err)
+	}
+
+	if err := list.DiveIn(); err != nil {
+		t.Fatalf("DiveIn() into a map error = %v", err)
+	}
+
+	entries, _ := list.Items()
+	if !reflect.DeepEqual(entries, []interface{}{Entry{Key: "dev", Value: "single"}, Entry{Key: "prod", Value: []string{"web", "db"}}}) {
+		t.Errorf("Items() = %v, want entries sorted by key", entries)
+	}
+
+	if err := list.DiveIn(); err == nil {
+		t.Errorf("DiveIn() into a leaf entry error = nil, want error")
+	}
+
+	list.Next()
+	if err := list.DiveIn(); err != nil {
+		t.Fatalf("DiveIn() into an entry error = %v", err)
+	}
+
+	items2, _ := list.Items()
+	if !reflect.DeepEqual(items2, []interface{}{"web", "db"}) {
+		t.Errorf("Items() = %v, want [web db]", items2)
+	}
+
+	if !reflect.DeepEqual(list.Index(), []int{0, 0, 1, 0}) {
+		t.Errorf("Index() = %v, want [0 0 1 0]", list.Index())
+	}
+
+	if err := list.DiveOut(); err != nil {
+		t.Fatalf("DiveOut() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(list.Index(), []int{0, 0, 1}) {
+		t.Errorf("Index() = %v, want [0 0 1]", list.Index())
+	}
+
+	if ancestors := list.Ancestors(); len(ancestors) != 2 || Label(ancestors[0]) != "eu" {
+		t.Errorf("Ancestors() = %v, want the region and the map", ancestors)
+	}
+
+	if _, err := New(map[string]interface{}{"a": 1}, 1); err != nil {
+		t.Errorf("New() of a map error = %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package multidimlist
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Row is a node of a tree as it is displayed, one per line, by a tree select.
+type Row struct {
+	// Item is the item of the node.
+	Item interface{}
+	// Path holds the indices of the node across dimensions, like the index returned by List.Index.
+	Path []int
+	// Branch tells whether the item holds nested items.
+	Branch bool
+	// Expanded tells whether the nested items of the branch are displayed below it.
+	Expanded bool
+}
+
+// Depth returns the number of ancestors of the row.
+func (r *Row) Depth() int {
+	return len(r.Path) - 1
+}
+
+// Tree holds nested items and displays them as rows, where the nested items of a branch are only displayed
+// once the branch is expanded. It navigates the same items as List.
+type Tree struct {
+	items    []interface{}
+	expanded map[string]bool
+
+	// Searcher is the function used for filtering the items.
+	Searcher Searcher
+}
+
+// NewTree creates a tree of the given items with all branches collapsed. The items attribute must be a
+// branch, as told by IsBranch. Error will be returned otherwise.
+func NewTree(items interface{}) (*Tree, error) {
+	values, ok := children(items)
+	if !ok {
+		return nil, fmt.Errorf("items %v is not a branch", items)
+	}
+
+	return &Tree{items: values, expanded: map[string]bool{}}, nil
+}
+
+// Rows returns the rows of the tree, each expanded branch being followed by its nested items.
+func (t *Tree) Rows() []*Row {
+	return t.rows(t.items, nil, func(row *Row, nested []*Row) ([]*Row, bool) {
+		if !row.Expanded {
+			return nil, true
+		}
+		return nested, true
+	})
+}
+
+// Search returns the rows of the items matching the given term along with their ancestors, which are
+// expanded to display them. The tree must implement the searcher function signature for this functionality
+// to work.
+func (t *Tree) Search(term string) []*Row {
+	term = strings.Trim(term, " ")
+
+	return t.rows(t.items, nil, func(row *Row, nested []*Row) ([]*Row, bool) {
+		row.Expanded = len(nested) > 0
+		return nested, row.Expanded || t.Searcher(term, row.Item, row.Path[len(row.Path)-1])
+	})
+}
+
+// rows walks through the items, asking keep which nested rows of each row to display and whether to keep
+// the row itself.
+func (t *Tree) rows(items []interface{}, path []int, keep func(row *Row, nested []*Row) ([]*Row, bool)) []*Row {
+	var result []*Row
+
+	for i, item := range items {
+		row := &Row{Item: item, Path: append(append([]int{}, path...), i)}
+
+		var nested []*Row
+		if values, ok := children(item); ok {
+			row.Branch = true
+			row.Expanded = t.IsExpanded(row.Path)
+			nested = t.rows(values, row.Path, keep)
+		}
+
+		nested, ok := keep(row, nested)
+		if ok {
+			result = append(result, row)
+			result = append(result, nested...)
+		}
+	}
+
+	return result
+}
+
+// Item returns the item at the given path and whether it exists.
+func (t *Tree) Item(path []int) (interface{}, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+
+	items := t.items
+	for i, index := range path {
+		if index < 0 || index >= len(items) {
+			return nil, false
+		}
+
+		if i == len(path)-1 {
+			return items[index], true
+		}
+
+		values, ok := children(items[index])
+		if !ok {
+			return nil, false
+		}
+		items = values
+	}
+
+	return nil, false
+}
+
+// IsExpanded returns whether the branch at the given path is expanded.
+func (t *Tree) IsExpanded(path []int) bool {
+	return t.expanded[fmt.Sprint(path)]
+}
+
+// Expand displays the nested items of the branch at the given path. It returns false if there is no branch
+// at that path.
+func (t *Tree) Expand(path []int) bool {
+	item, ok := t.Item(path)
+	if !ok {
+		return false
+	}
+
+	if _, ok := children(item); !ok {
+		return false
+	}
+
+	t.expanded[fmt.Sprint(path)] = true
+	return true
+}
+
+// Collapse hides the nested items of the branch at the given path. It returns false if the branch was not
+// expanded.
+func (t *Tree) Collapse(path []int) bool {
+	key := fmt.Sprint(path)
+	if !t.expanded[key] {
+		return false
+	}
+
+	delete(t.expanded, key)
+	return true
+}
+
+// ExpandAll expands every branch of the tree.
+func (t *Tree) ExpandAll() {
+	t.rows(t.items, nil, func(row *Row, nested []*Row) ([]*Row, bool) {
+		if row.Branch {
+			t.expanded[fmt.Sprint(row.Path)] = true
+		}
+		return nil, false
+	})
+}
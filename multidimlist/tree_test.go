@@ -0,0 +1,85 @@
+package multidimlist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func rowPaths(rows []*Row) [][]int {
+	var paths [][]int
+	for _, row := range rows {
+		paths = append(paths, row.Path)
+	}
+	return paths
+}
+
+func TestTree(t *testing.T) {
+	items := []interface{}{
+		"a",
+		[]interface{}{"b1", "b2"},
+		[]interface{}{"c1", []string{"c21", "c22"}},
+	}
+
+	tree, err := NewTree(items)
+	if err != nil {
+		t.Fatalf("Failed to create new tree: %v", err)
+	}
+
+	if paths := rowPaths(tree.Rows()); !reflect.DeepEqual(paths, [][]int{{0}, {1}, {2}}) {
+		t.Errorf("Rows() = %v, want collapsed branches", paths)
+	}
+
+	if tree.Expand([]int{0}) {
+		t.Errorf("Expand() of a leaf = true, want false")
+	}
+
+	if !tree.Expand([]int{2}) || !tree.Expand([]int{2, 1}) {
+		t.Fatalf("Expand() of a branch = false, want true")
+	}
+
+	rows := tree.Rows()
+	if paths := rowPaths(rows); !reflect.DeepEqual(paths, [][]int{{0}, {1}, {2}, {2, 0}, {2, 1}, {2, 1, 0}, {2, 1, 1}}) {
+		t.Errorf("Rows() = %v, want expanded branches", paths)
+	}
+
+	if !rows[2].Branch || !rows[2].Expanded || rows[1].Expanded || rows[5].Depth() != 2 {
+		t.Errorf("Rows() = %v, want branch state", rows)
+	}
+
+	if !tree.Collapse([]int{2}) || tree.Collapse([]int{2}) {
+		t.Errorf("Collapse() should only collapse expanded branches")
+	}
+
+	if paths := rowPaths(tree.Rows()); !reflect.DeepEqual(paths, [][]int{{0}, {1}, {2}}) {
+		t.Errorf("Rows() = %v, want collapsed branch", paths)
+	}
+
+	item, ok := tree.Item([]int{2, 1, 0})
+	if !ok || item != "c21" {
+		t.Errorf("Item() = %v, %v, want c21", item, ok)
+	}
+
+	if _, ok := tree.Item([]int{0, 1}); ok {
+		t.Errorf("Item() inside a leaf should not exist")
+	}
+
+	tree.Searcher = func(input string, item interface{}, index int) bool {
+		s, ok := item.(string)
+		return ok && strings.Contains(s, input)
+	}
+
+	rows = tree.Search("c2")
+	if paths := rowPaths(rows); !reflect.DeepEqual(paths, [][]int{{2}, {2, 1}, {2, 1, 0}, {2, 1, 1}}) {
+		t.Errorf("Search() = %v, want matches with their ancestors", paths)
+	}
+
+	if !rows[0].Expanded || !rows[1].Expanded {
+		t.Errorf("Search() should expand the ancestors of matches")
+	}
+
+	tree.ExpandAll()
+	if paths := rowPaths(tree.Rows()); len(paths) != 9 {
+		t.Errorf("ExpandAll() rows = %v, want all rows", paths)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sync"
 	"text/tabwriter"
 	"text/template"
 
@@ -12,15 +13,74 @@ import (
 	"github.com/lemotw/promptui/screenbuf"
 )
 
+// columnSeparator separates the columns of a MultidimSelect displaying its layers side by side.
+const columnSeparator = " │ "
+
+// defaultWidth is the width of the terminal used when it can't be detected.
+const defaultWidth = 80
+
+// SelectionPolicy is a function telling whether an item of a MultidimSelect can be selected. It receives the
+// item, its index across dimensions and whether it is a branch. The items loaded lazily are branches until
+// their loading returns no nested items, which happens when enter is pressed on them.
+type SelectionPolicy func(item interface{}, index []int, branch bool) bool
+
+// SelectAny is the selection policy allowing any item to be selected.
+func SelectAny(item interface{}, index []int, branch bool) bool {
+	return true
+}
+
+// SelectLeafOnly is the selection policy allowing only the items that are not branches to be selected.
+func SelectLeafOnly(item interface{}, index []int, branch bool) bool {
+	return !branch
+}
+
+// SelectBranchOnly is the selection policy allowing only the branches to be selected.
+func SelectBranchOnly(item interface{}, index []int, branch bool) bool {
+	return branch
+}
+
 // EnterCallback is a function that is called when the user presses enter
-// The function should return true if the select should exit
+// The function should return true if the select should exit. An error ends the select.
+//
+// Deprecated: use EnterHandler, which can drive the select without ending it.
 type EnterCallback func(item interface{}, cursor []int) (bool, error)
 
+// EnterActionKind tells what a MultidimSelect does once its enter handler returns.
+type EnterActionKind int
+
+const (
+	// EnterExit selects the item and exits the select.
+	EnterExit EnterActionKind = iota
+	// EnterStay keeps the select running as it is. Along with a message, it shows the message.
+	EnterStay
+	// EnterDiveIn moves the cursor into the nested items of the item.
+	EnterDiveIn
+	// EnterReplace replaces the items of the current layer with the items of the action, for example to
+	// refresh them after the item was changed.
+	EnterReplace
+)
+
+// EnterAction is the action returned by an EnterHandler.
+type EnterAction struct {
+	// Kind is what the select does.
+	Kind EnterActionKind
+	// Items are the new items of the current layer for EnterReplace. They must be a branch.
+	Items interface{}
+	// Message is displayed below the items with the Status template when the select keeps running.
+	Message string
+}
+
+// EnterHandler is a function that is called when the user presses enter on an item that can be selected.
+// It receives the item and its index across dimensions and returns the action the select takes. An error is
+// displayed below the items with the Error template and keeps the select running.
+type EnterHandler func(item interface{}, index []int) (EnterAction, error)
+
 // MultidimSelect is a select list that allows the user to navigate through a list of items
 type MultidimSelect struct {
 	// Label is the text displayed on top of the list
 	Label interface{}
-	// Items are the items to display inside the list
+	// Items are the items to display inside the list. Branches holding nested items are either slices, maps
+	// of labels to their nested items or values implementing multidimlist.Node.
 	Items interface{}
 
 	// Templates can be used to customize the select output
@@ -35,12 +95,32 @@ type MultidimSelect struct {
 	// A function that determines how to render the cursor
 	Pointer Pointer
 	// EnterCallback is a function that is called when the user presses enter
+	//
+	// Deprecated: use OnEnter.
 	EnterCallback EnterCallback
+	// OnEnter is a function that is called when the user presses enter on an item that can be selected and
+	// tells whether to exit, stay, dive in or replace the items of the current layer. It takes precedence
+	// over EnterCallback.
+	OnEnter EnterHandler
+	// SelectionPolicy tells which items can be selected. Items that can't be selected are displayed with the
+	// Disabled template, or the ActiveDisabled one under the cursor, and pressing enter on them dives into
+	// them instead when possible. Defaults to SelectAny.
+	SelectionPolicy SelectionPolicy
+	// LoadChildren is a function that loads the nested items of an item the first time the cursor dives into
+	// it, for items that are not branches. The loading template is displayed during the loading and the
+	// Error template when it fails.
+	LoadChildren multidimlist.ChildrenLoader
 	// Searcher is a function for filtering items
 	Searcher multidimlist.Searcher
+	// DeepSearch sets whether searching walks through all the layers of the list, listing every matching
+	// item that is not a branch along with the path leading to it. Selecting a match returns its full index.
+	DeepSearch bool
 
 	// Size is the number of items that should appear
 	Size int
+	// Columns sets whether to display the layer the cursor dived out of, the current layer and the nested
+	// items of the active item side by side, sharing the width of the terminal
+	Columns bool
 	// CursorPos is the initial position of the cursor
 	CursorPos int
 
@@ -72,15 +152,21 @@ type MultidimSelectKeys struct {
 
 // MultidimSelectTemplates allows customizing the display
 // You can use the FuncMap to add custom functions to the templates.
-// joinSlice, isSlice, sliceLen, sliceItem and joinMap are available by default.
+// joinSlice, isSlice, sliceLen, sliceItem and nodeLabel are available by default.
 type MultidimSelectTemplates struct {
 	// Compiled templates
-	label    *template.Template
-	active   *template.Template
-	inactive *template.Template
-	selected *template.Template
-	details  *template.Template
-	help     *template.Template
+	label          *template.Template
+	active         *template.Template
+	inactive       *template.Template
+	selected       *template.Template
+	disabled       *template.Template
+	activeDisabled *template.Template
+	details        *template.Template
+	help           *template.Template
+	breadcrumb     *template.Template
+	loading        *template.Template
+	err            *template.Template
+	status         *template.Template
 	// Function map for template execution
 	FuncMap template.FuncMap
 
@@ -92,10 +178,27 @@ type MultidimSelectTemplates struct {
 	Inactive string
 	// Selected is the template for when an item is chosen
 	Selected string
+	// Disabled is the template for non-selected items that can't be chosen, as told by the selection policy
+	Disabled string
+	// ActiveDisabled is the template for the currently selected item when it can't be chosen. Pressing enter
+	// dives into it instead when possible.
+	ActiveDisabled string
 	// Details is the template for additional item information
 	Details string
 	// Help is the template for help text
 	Help string
+	// Breadcrumb is the template for the path of the current layer, displayed once the cursor dived in.
+	// It receives the items the cursor dived into, starting from the root layer. Pressing a number key
+	// jumps back to that layer, 0 being the root layer.
+	Breadcrumb string
+	// Loading is the template displayed while the nested items of the item it receives are loaded
+	Loading string
+	// Error is the template displayed below the items when loading nested items or handling enter fails.
+	// It receives the error.
+	Error string
+	// Status is the template for the message of the action returned by the enter handler, displayed below
+	// the items. It receives the message.
+	Status string
 }
 
 // Run executes the select list
@@ -114,6 +217,8 @@ func (s *MultidimSelect) RunCursorAt(cursorPos, scroll int) ([]int, interface{},
 		return nil, "", err
 	}
 	l.Searcher = s.Searcher
+	l.LoadChildren = s.LoadChildren
+	l.DeepSearch = s.DeepSearch
 	s.list = l
 
 	s.setKeys()
@@ -127,23 +232,8 @@ func (s *MultidimSelect) RunCursorAt(cursorPos, scroll int) ([]int, interface{},
 }
 
 func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, interface{}, error) {
-	// Wrap stdin to intercept input
-	stdinWrapper := newStdinWrapper(s.Stdin, func(p []byte) (bool, error) {
-		switch (rune)(p[0]) {
-		case KeyEnter:
-			items, idx := s.list.Items()
-			item := items[idx]
-
-			// If the enter callback returns true, exit the select
-			if s.EnterCallback != nil {
-				return s.EnterCallback(item, s.list.GetCursor())
-			}
-		}
-		return true, nil
-	})
-
 	c := &readline.Config{
-		Stdin:  stdinWrapper,
+		Stdin:  s.Stdin,
 		Stdout: s.Stdout,
 	}
 	err := c.Init()
@@ -175,52 +265,8 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 	s.list.SetCursor(cursorPos)
 	s.list.SetStart(scroll)
 
-	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
-		switch {
-		case key == KeyEnter:
-			return nil, 0, false
-		case key == s.Keys.Next.Code || (key == 'j' && !searchMode):
-			s.list.Next()
-		case key == s.Keys.Prev.Code || (key == 'k' && !searchMode):
-			s.list.Prev()
-		case key == s.Keys.DiveIn.Code:
-			s.list.DiveIn()
-		case key == s.Keys.DiveOut.Code:
-			s.list.DiveOut()
-		case key == s.Keys.Search.Code:
-			if !canSearch {
-				break
-			}
-
-			if searchMode {
-				searchMode = false
-				cur.Replace("")
-				s.list.CancelSearch()
-			} else {
-				searchMode = true
-			}
-		case key == KeyBackspace || key == KeyCtrlH:
-			if !canSearch || !searchMode {
-				break
-			}
-
-			cur.Backspace()
-			if len(cur.Get()) > 0 {
-				s.list.Search(cur.Get())
-			} else {
-				s.list.CancelSearch()
-			}
-		case key == s.Keys.PageUp.Code || (key == 'h' && !searchMode):
-			s.list.DiveOut()
-		case key == s.Keys.PageDown.Code || (key == 'l' && !searchMode):
-			s.list.DiveIn()
-		default:
-			if canSearch && searchMode {
-				cur.Update(string(line))
-				s.list.Search(cur.Get())
-			}
-		}
-
+	// draw renders the select with the given status line below the items
+	draw := func(status []byte) {
 		if searchMode {
 			header := SearchPrompt + cur.Format()
 			sb.WriteString(header)
@@ -229,12 +275,17 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 			sb.Write(help)
 		}
 
+		if ancestors := s.list.Ancestors(); len(ancestors) > 0 {
+			sb.Write(render(s.Templates.breadcrumb, ancestors))
+		}
+
 		label := render(s.Templates.label, s.Label)
 		sb.Write(label)
 
 		items, idx := s.list.Items()
 		last := len(items) - 1
 
+		var rows [][]byte
 		for i, item := range items {
 			page := " "
 			switch i {
@@ -252,13 +303,28 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 
 			output := []byte(page + " ")
 
-			if i == idx {
+			selectable := s.selectable(item, s.list.ItemIndex(i))
+
+			switch {
+			case i == idx && !selectable:
+				output = append(output, render(s.Templates.activeDisabled, item)...)
+			case i == idx:
 				output = append(output, render(s.Templates.active, item)...)
-			} else {
+			case !selectable:
+				output = append(output, render(s.Templates.disabled, item)...)
+			default:
 				output = append(output, render(s.Templates.inactive, item)...)
 			}
 
-			sb.Write(output)
+			rows = append(rows, output)
+		}
+
+		if s.Columns {
+			s.writeColumns(sb, rows, c.FuncGetWidth())
+		} else {
+			for _, row := range rows {
+				sb.Write(row)
+			}
 		}
 
 		if idx == multidimlist.NotFound {
@@ -272,7 +338,181 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 			}
 		}
 
+		if status != nil {
+			sb.Write(status)
+		}
+
 		sb.Flush()
+	}
+
+	// dived restores the search of the layer the cursor moved to
+	dived := func(err error) {
+		if err != nil {
+			return
+		}
+
+		cur.Replace(s.list.Term())
+		searchMode = s.list.Term() != ""
+	}
+
+	// diveIn dives into the active item, displaying the loading of its nested items if needed
+	diveIn := func() []byte {
+		if s.list.NeedsLoading() {
+			items, idx := s.list.Items()
+			draw(render(s.Templates.loading, items[idx]))
+		}
+
+		err := s.list.DiveIn()
+		if err, ok := err.(*multidimlist.LoadError); ok {
+			return render(s.Templates.err, err)
+		}
+
+		dived(err)
+		return nil
+	}
+
+	// rejected tells whether the last enter keeps the select running, either because the item can't be
+	// selected or because of the enter handler. It is set before readline handles the key, so that it is
+	// known by the time the line ends. The action to take is then kept in action, or in actionErr when the
+	// handler failed, and failed holds the error of the enter callback ending the select.
+	rejected := false
+	var action EnterAction
+	var actionErr, failed error
+
+	// mu guards the list and the state above, as readline keeps handling keys once a line ends
+	var mu sync.Mutex
+
+	c.FuncFilterInputRune = func(key rune) (rune, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if key != KeyEnter {
+			return key, true
+		}
+
+		rejected = false
+		action, actionErr = EnterAction{}, nil
+
+		items, idx := s.list.Items()
+		if idx == multidimlist.NotFound {
+			return key, true
+		}
+
+		item, index := unwrapMatch(items[idx]), s.list.Index()
+
+		// the selection policy is told whether the item is a branch once it is loaded
+		if s.SelectionPolicy != nil && s.list.NeedsLoading() {
+			draw(render(s.Templates.loading, items[idx]))
+
+			actionErr = s.list.Load()
+			if actionErr != nil {
+				rejected = true
+				return key, true
+			}
+		}
+
+		switch {
+		case !s.selectable(items[idx], index):
+			action.Kind = EnterDiveIn
+		case s.OnEnter != nil:
+			action, actionErr = s.OnEnter(item, index)
+		case s.EnterCallback != nil:
+			ok, err := s.EnterCallback(item, s.list.GetCursor())
+			if err != nil {
+				failed = err
+			} else if !ok {
+				action.Kind = EnterStay
+			}
+		}
+
+		rejected = actionErr != nil || action.Kind != EnterExit
+
+		return key, true
+	}
+
+	// enter takes the action of the enter handler
+	enter := func() []byte {
+		if actionErr != nil {
+			return render(s.Templates.err, actionErr)
+		}
+
+		switch action.Kind {
+		case EnterDiveIn:
+			if status := diveIn(); status != nil {
+				return status
+			}
+		case EnterReplace:
+			err := s.list.Replace(action.Items)
+			if err != nil {
+				return render(s.Templates.err, err)
+			}
+		}
+
+		if action.Message != "" {
+			return render(s.Templates.status, action.Message)
+		}
+
+		return nil
+	}
+
+	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var status []byte
+
+		switch {
+		case key == 0:
+			// readline calls the listener without a key each time it starts reading a line
+		case key == KeyEnter && !rejected:
+			return nil, 0, false
+		case key == KeyEnter:
+			status = enter()
+		case key == s.Keys.Next.Code || (key == 'j' && !searchMode):
+			s.list.Next()
+		case key == s.Keys.Prev.Code || (key == 'k' && !searchMode):
+			s.list.Prev()
+		case key == s.Keys.DiveIn.Code:
+			status = diveIn()
+		case key == s.Keys.DiveOut.Code:
+			dived(s.list.DiveOut())
+		case key == s.Keys.Search.Code:
+			if !canSearch {
+				break
+			}
+
+			if searchMode {
+				searchMode = false
+				cur.Replace("")
+				s.list.CancelSearch()
+			} else {
+				searchMode = true
+			}
+		case key == KeyBackspace || key == KeyCtrlH:
+			if !canSearch || !searchMode {
+				break
+			}
+
+			cur.Backspace()
+			if len(cur.Get()) > 0 {
+				s.list.Search(cur.Get())
+			} else {
+				s.list.CancelSearch()
+			}
+		case key == s.Keys.PageUp.Code || (key == 'h' && !searchMode):
+			dived(s.list.DiveOut())
+		case key == s.Keys.PageDown.Code || (key == 'l' && !searchMode):
+			status = diveIn()
+		case key >= '0' && key <= '9' && !searchMode:
+			dived(s.list.DiveOutTo(int(key - '0')))
+		default:
+			if canSearch && searchMode {
+				cur.Update(string(line))
+				s.list.Search(cur.Get())
+			}
+		}
+
+		draw(status)
 
 		return nil, 0, true
 	})
@@ -290,8 +530,13 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 			break
 		}
 
+		mu.Lock()
 		_, idx := s.list.Items()
-		if idx != multidimlist.NotFound {
+		done := failed != nil || (idx != multidimlist.NotFound && !rejected)
+		err = failed
+		mu.Unlock()
+
+		if done {
 			break
 		}
 	}
@@ -308,8 +553,10 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 		return nil, nil, err
 	}
 
+	mu.Lock()
 	items, idx := s.list.Items()
-	item := items[idx]
+	item, index := unwrapMatch(items[idx]), s.list.Index()
+	mu.Unlock()
 
 	if s.HideSelected {
 		clearScreen(sb)
@@ -322,7 +569,7 @@ func (s *MultidimSelect) innerRun(cursorPos, scroll int, top rune) ([]int, inter
 	rl.Write([]byte(showCursor))
 	rl.Close()
 
-	return s.list.Index(), item, err
+	return index, item, err
 }
 
 func (s *MultidimSelect) setKeys() {
@@ -363,7 +610,7 @@ func (s *MultidimSelect) prepareTemplates() error {
             {{- if isSlice . -}}
                 %s {{ joinSlice " & " . | underline }}
             {{- else -}}
-                %s {{ . | underline }}
+                %s {{ nodeLabel . | underline }}
             {{- end -}}
         `, IconSelect, IconSelect)
 	}
@@ -379,7 +626,7 @@ func (s *MultidimSelect) prepareTemplates() error {
             {{- if isSlice . -}}
                     {{ joinSlice " & " . }}
             {{- else -}}
-                    {{ . }}
+                    {{ nodeLabel . }}
             {{- end -}}
         `
 	}
@@ -390,12 +637,44 @@ func (s *MultidimSelect) prepareTemplates() error {
 	}
 	tpls.inactive = tpl
 
+	if tpls.Disabled == "" {
+		tpls.Disabled = `
+            {{- if isSlice . -}}
+                    {{ joinSlice " & " . | faint }}
+            {{- else -}}
+                    {{ nodeLabel . | faint }}
+            {{- end -}}
+        `
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Disabled)
+	if err != nil {
+		return err
+	}
+	tpls.disabled = tpl
+
+	if tpls.ActiveDisabled == "" {
+		tpls.ActiveDisabled = fmt.Sprintf(`
+            {{- if isSlice . -}}
+                %s {{ joinSlice " & " . | faint | underline }}
+            {{- else -}}
+                %s {{ nodeLabel . | faint | underline }}
+            {{- end -}}
+        `, IconSelect, IconSelect)
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.ActiveDisabled)
+	if err != nil {
+		return err
+	}
+	tpls.activeDisabled = tpl
+
 	if tpls.Selected == "" {
 		tpls.Selected = fmt.Sprintf(`
 			{{ if isSlice . }}
 				{{ "%s" | green}} {{ joinSlice " & " . }}
 			{{ else }}
-			 	{{ "%s" | green}} {{ . }}
+			 	{{ "%s" | green}} {{ nodeLabel . }}
 			{{ end }}
 		`, IconGood, IconGood)
 	}
@@ -418,7 +697,8 @@ func (s *MultidimSelect) prepareTemplates() error {
 		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} ` +
 			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} ` +
 			`{{ "Dimensions:" | faint }} {{ .DiveInKey | faint }} {{ .DiveOutKey | faint }} ` +
-			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}`)
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}` +
+			`{{ if .Depth }} {{ "0-9" | faint }} {{ "jumps back to a layer" | faint }}{{ end }}`)
 	}
 
 	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Help)
@@ -427,6 +707,47 @@ func (s *MultidimSelect) prepareTemplates() error {
 	}
 	tpls.help = tpl
 
+	if tpls.Breadcrumb == "" {
+		tpls.Breadcrumb = `{{ "root" | faint }}` +
+			`{{ range . }} {{ "›" | faint }} {{ if isSlice . }}{{ joinSlice " & " . }}{{ else }}{{ nodeLabel . }}{{ end }}{{ end }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Breadcrumb)
+	if err != nil {
+		return err
+	}
+	tpls.breadcrumb = tpl
+
+	if tpls.Loading == "" {
+		tpls.Loading = `{{ "Loading" | faint }} {{ nodeLabel . | faint }}{{ "..." | faint }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Loading)
+	if err != nil {
+		return err
+	}
+	tpls.loading = tpl
+
+	if tpls.Error == "" {
+		tpls.Error = fmt.Sprintf(`{{ "%s" | red }} {{ . | red }}`, IconBad)
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Error)
+	if err != nil {
+		return err
+	}
+	tpls.err = tpl
+
+	if tpls.Status == "" {
+		tpls.Status = fmt.Sprintf(`{{ "%s" | cyan }} {{ . }}`, IconInitial)
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Status)
+	if err != nil {
+		return err
+	}
+	tpls.status = tpl
+
 	s.Templates = tpls
 
 	return nil
@@ -450,6 +771,43 @@ func (s *MultidimSelect) renderDetails(item interface{}) [][]byte {
 	return bytes.Split(output, []byte("\n"))
 }
 
+// writeColumns writes the rows of the current layer between the parent layer and the nested items of the
+// active item, fitting the given width.
+func (s *MultidimSelect) writeColumns(sb *screenbuf.ScreenBuf, rows [][]byte, width int) {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	// keep the last cell free so that lines never wrap
+	available := width - 2*len([]rune(columnSeparator)) - 1
+	side := available / 4
+	widths := []int{side, available - 2*side, side}
+
+	var parent [][]byte
+	items, idx := s.list.Parent()
+	start := 0
+	if idx >= s.Size {
+		start = idx - s.Size + 1
+	}
+	for i := start; i < len(items) && i < start+s.Size; i++ {
+		if i == idx {
+			parent = append(parent, render(s.Templates.active, items[i]))
+		} else {
+			parent = append(parent, append([]byte("  "), render(s.Templates.inactive, items[i])...))
+		}
+	}
+
+	var preview [][]byte
+	for i, item := range s.list.Preview() {
+		if i == s.Size {
+			break
+		}
+		preview = append(preview, append([]byte("  "), render(s.Templates.inactive, item)...))
+	}
+
+	sb.WriteColumns(widths, columnSeparator, parent, rows, preview)
+}
+
 func (s *MultidimSelect) renderHelp(search bool) []byte {
 	keys := struct {
 		NextKey     string
@@ -460,6 +818,7 @@ func (s *MultidimSelect) renderHelp(search bool) []byte {
 		DiveOutKey  string
 		SearchKey   string
 		Search      bool
+		Depth       int
 	}{
 		NextKey:     s.Keys.Next.Display,
 		PrevKey:     s.Keys.Prev.Display,
@@ -469,53 +828,24 @@ func (s *MultidimSelect) renderHelp(search bool) []byte {
 		DiveOutKey:  s.Keys.DiveOut.Display,
 		SearchKey:   s.Keys.Search.Display,
 		Search:      search,
+		Depth:       len(s.list.GetCursor()) - 1,
 	}
 
 	return render(s.Templates.help, keys)
 }
 
-// for hijacking stdin
-type stdinWrapper struct {
-	original io.Reader
-	onRead   func([]byte) (bool, error) // Callback for intercepting reads
-}
-
-func newStdinWrapper(original io.Reader, onRead func([]byte) (bool, error)) *stdinWrapper {
-	if original == nil {
-		original = readline.Stdin
-	}
-
-	return &stdinWrapper{
-		original: original,
-		onRead:   onRead,
-	}
-}
-
-func (w *stdinWrapper) Read(p []byte) (n int, err error) {
-	// First read from original stdin
-	n, err = w.original.Read(p)
-	if err != nil {
-		return n, err
+// selectable returns whether the item at the given index can be selected according to the selection policy.
+func (s *MultidimSelect) selectable(item interface{}, index []int) bool {
+	if s.SelectionPolicy == nil {
+		return true
 	}
-
-	// If callback is set, process the input
-	if w.onRead != nil {
-		// Call callback to check if input is allowed
-		if shouldPass, callbackErr := w.onRead(p[:n]); !shouldPass {
-			if callbackErr != nil {
-				return 0, callbackErr
-			}
-			// If input is not allowed, return 0 length
-			return 0, nil
-		}
-	}
-
-	return n, err
+	return s.SelectionPolicy(unwrapMatch(item), index, s.list.IsBranchAt(index, item))
 }
 
-func (w *stdinWrapper) Close() error {
-	if closer, ok := w.original.(io.Closer); ok {
-		return closer.Close()
+// unwrapMatch returns the item found by a deep search or the given item if it is not a match.
+func unwrapMatch(item interface{}) interface{} {
+	if match, ok := item.(*multidimlist.Match); ok {
+		return match.Item
 	}
-	return nil
+	return item
 }
@@ -1,8 +1,14 @@
 package promptui
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/lemotw/promptui/multidimlist"
 )
 
 func TestMultidimSelectTemplateRender(t *testing.T) {
@@ -182,3 +188,413 @@ func TestMultidimSelectTemplateRender(t *testing.T) {
 		}
 	})
 }
+
+func TestMultidimSelectBreadcrumb(t *testing.T) {
+	items := []interface{}{
+		"Option 1",
+		[]interface{}{
+			"Option 2.1",
+			[]interface{}{"Option 2.2.1", "Option 2.2.2"},
+		},
+	}
+
+	t.Run("when rendering the ancestors", func(t *testing.T) {
+		s := MultidimSelect{Label: "Select Number", Items: items}
+
+		err := s.prepareTemplates()
+		if err != nil {
+			t.Fatalf("Unexpected error preparing templates %v", err)
+		}
+
+		result := string(render(s.Templates.breadcrumb, []interface{}{items[1], "Option 2.1"}))
+		exp := "\x1b[2mroot\x1b[0m \x1b[2m›\x1b[0m Option 2.1 & [Option 2.2.1 Option 2.2.2] \x1b[2m›\x1b[0m Option 2.1"
+		if result != exp {
+			t.Errorf("Expected breadcrumb to eq %q, got %q", exp, result)
+		}
+	})
+
+	tcs := []struct {
+		name  string
+		input string
+		index []int
+	}{
+		{name: "when diving in", input: "j\x06j\x06j\r", index: []int{1, 1, 1}},
+		{name: "when jumping back to the root", input: "j\x06j\x060\r", index: []int{1}},
+		{name: "when jumping back to a layer", input: "j\x06j\x061\r", index: []int{1, 1}},
+		{name: "when jumping to the current layer", input: "j\x06j\x06j2\r", index: []int{1, 1, 1}},
+		{name: "when diving back into a layer", input: "j\x06j\x06j00\x06\x06\r", index: []int{1, 1, 1}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout := &closeBuffer{}
+			s := MultidimSelect{
+				Label:  "Select Number",
+				Items:  items,
+				Stdin:  ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout: stdout,
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if !reflect.DeepEqual(index, tc.index) {
+				t.Errorf("Expected index %v, got %v", tc.index, index)
+			}
+
+			if !strings.Contains(stripCodes(stdout.String()), "root › Option 2.1 & [Option 2.2.1 Option 2.2.2]") {
+				t.Errorf("Expected the breadcrumb in the output, got %q", stdout.String())
+			}
+		})
+	}
+}
+
+func TestMultidimSelectNodes(t *testing.T) {
+	items := map[string]interface{}{
+		"eu-west": map[string]interface{}{
+			"cluster-a": []string{"default", "kube-system"},
+		},
+		"us-east": []string{"default"},
+	}
+
+	stdout := &closeBuffer{}
+	s := MultidimSelect{
+		Label:  "Namespace",
+		Items:  items,
+		Stdin:  ioutil.NopCloser(strings.NewReader("\x06\x06j\r")),
+		Stdout: stdout,
+	}
+
+	index, item, err := s.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error running select %v", err)
+	}
+
+	if !reflect.DeepEqual(index, []int{0, 0, 1}) || item != "kube-system" {
+		t.Errorf("Expected [0 0 1] kube-system, got %v %v", index, item)
+	}
+
+	output := stripCodes(stdout.String())
+	for _, exp := range []string{"▸ eu-west", "us-east", "root › eu-west › cluster-a"} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got %q", exp, output)
+		}
+	}
+}
+
+func TestMultidimSelectLoadChildren(t *testing.T) {
+	stdout := &closeBuffer{}
+	s := MultidimSelect{
+		Label:  "Instance",
+		Items:  []string{"broken", "account"},
+		Stdin:  ioutil.NopCloser(strings.NewReader("\x06j\x06j\r")),
+		Stdout: stdout,
+		LoadChildren: func(path []int, item interface{}) ([]interface{}, error) {
+			if item == "broken" {
+				return nil, errors.New("permission denied")
+			}
+			return []interface{}{"web", "db"}, nil
+		},
+	}
+
+	index, item, err := s.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error running select %v", err)
+	}
+
+	if !reflect.DeepEqual(index, []int{1, 1}) || item != "db" {
+		t.Errorf("Expected [1 1] db, got %v %v", index, item)
+	}
+
+	output := stripCodes(stdout.String())
+	for _, exp := range []string{"✗ permission denied", "Loading account...", "root › account"} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got %q", exp, output)
+		}
+	}
+}
+
+func TestMultidimSelectDeepSearch(t *testing.T) {
+	items := map[string]interface{}{
+		"eu-west": map[string]interface{}{
+			"cluster-a": []string{"default", "kube-system"},
+		},
+		"us-east": []string{"default"},
+	}
+
+	stdout := &closeBuffer{}
+	s := MultidimSelect{
+		Label:      "Namespace",
+		Items:      items,
+		DeepSearch: true,
+		Searcher: func(input string, item interface{}, index int) bool {
+			return strings.Contains(item.(string), input)
+		},
+		Stdin:  ioutil.NopCloser(strings.NewReader("/defx\x08\x0e\r")),
+		Stdout: stdout,
+	}
+
+	index, item, err := s.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error running select %v", err)
+	}
+
+	if !reflect.DeepEqual(index, []int{1, 0}) || item != "default" {
+		t.Errorf("Expected [1 0] default, got %v %v", index, item)
+	}
+
+	output := stripCodes(stdout.String())
+	if !strings.Contains(output, "eu-west › cluster-a › default") {
+		t.Errorf("Expected output to contain the path of the matches, got %q", output)
+	}
+}
+
+func TestMultidimSelectColumns(t *testing.T) {
+	items := []interface{}{
+		"Option 1",
+		[]interface{}{"Option 2.1", "Option 2.2"},
+	}
+
+	stdout := &closeBuffer{}
+	s := MultidimSelect{
+		Label:   "Select Number",
+		Items:   items,
+		Columns: true,
+		Stdin:   ioutil.NopCloser(strings.NewReader("j\x06j\r")),
+		Stdout:  stdout,
+	}
+
+	index, item, err := s.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error running select %v", err)
+	}
+
+	if !reflect.DeepEqual(index, []int{1, 1}) || item != "Option 2.2" {
+		t.Errorf("Expected [1 1] Option 2.2, got %v %v", index, item)
+	}
+
+	lines := strings.Split(stripCodes(stdout.String()), "\n")
+
+	// the nested items of the active item are displayed beside the current layer
+	if !containsLine(lines, "│   Option 1", "│   Option 2.1") {
+		t.Errorf("Expected a preview of the nested items, got %q", lines)
+	}
+
+	// the current layer is displayed beside the layer the cursor dived out of
+	if !containsLine(lines, "▸ Option 2.1 & Opt │", "│   ▸ Option 2.2") {
+		t.Errorf("Expected the parent layer beside the current one, got %q", lines)
+	}
+}
+
+// containsLine returns whether any of the lines contains all the given parts.
+func containsLine(lines []string, parts ...string) bool {
+	for _, line := range lines {
+		found := true
+		for _, part := range parts {
+			found = found && strings.Contains(line, part)
+		}
+
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestMultidimSelectSelectionPolicy(t *testing.T) {
+	items := []interface{}{
+		"Option 1",
+		[]interface{}{"Option 2.1", "Option 2.2"},
+	}
+
+	tcs := []struct {
+		name   string
+		policy SelectionPolicy
+		input  string
+		index  []int
+	}{
+		{name: "when any item can be selected", input: "j\r", index: []int{1}},
+		{name: "when diving into a branch under leaf only", policy: SelectLeafOnly, input: "j\rj\r", index: []int{1, 1}},
+		{name: "when selecting a leaf under leaf only", policy: SelectLeafOnly, input: "\r", index: []int{0}},
+		{name: "when selecting a leaf under branch only", policy: SelectBranchOnly, input: "\rj\r", index: []int{1}},
+		{
+			name: "when using a custom policy",
+			policy: func(item interface{}, index []int, branch bool) bool {
+				return len(index) == 2 && index[1] == 1
+			},
+			input: "\rj\r\rj\r",
+			index: []int{1, 1},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := MultidimSelect{
+				Label:           "Select Number",
+				Items:           items,
+				SelectionPolicy: tc.policy,
+				Stdin:           ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:          &closeBuffer{},
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if !reflect.DeepEqual(index, tc.index) {
+				t.Errorf("Expected index %v, got %v", tc.index, index)
+			}
+		})
+	}
+
+	t.Run("when rendering items that can't be selected", func(t *testing.T) {
+		s := MultidimSelect{Items: items}
+
+		err := s.prepareTemplates()
+		if err != nil {
+			t.Fatalf("Unexpected error preparing templates %v", err)
+		}
+
+		result := string(render(s.Templates.disabled, items[1]))
+		exp := "\x1b[2mOption 2.1 & Option 2.2\x1b[0m"
+		if result != exp {
+			t.Errorf("Expected disabled item to eq %q, got %q", exp, result)
+		}
+	})
+}
+
+func TestMultidimSelectSelectionPolicyLoading(t *testing.T) {
+	load := func(path []int, item interface{}) ([]interface{}, error) {
+		if item == "account" {
+			return []interface{}{"project-1", "project-2"}, nil
+		}
+		return nil, nil
+	}
+
+	tcs := []struct {
+		name   string
+		policy SelectionPolicy
+		input  string
+		index  []int
+		output string
+	}{
+		{name: "when diving into an unloaded item under leaf only", policy: SelectLeafOnly, input: "\rj\r", index: []int{0, 1}, output: "disabled account"},
+		{name: "when selecting an unloaded leaf under leaf only", policy: SelectLeafOnly, input: "j\r", index: []int{1}, output: "disabled leaf"},
+		{name: "when selecting an unloaded item under branch only", policy: SelectBranchOnly, input: "\r", index: []int{0}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			s := MultidimSelect{
+				Label:           "Resource",
+				Items:           []interface{}{"account", "leaf"},
+				SelectionPolicy: tc.policy,
+				LoadChildren:    load,
+				Templates:       &MultidimSelectTemplates{ActiveDisabled: "disabled {{ . }}"},
+				Stdin:           ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:          out,
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if !reflect.DeepEqual(index, tc.index) {
+				t.Errorf("Expected index %v, got %v", tc.index, index)
+			}
+
+			if !strings.Contains(stripCodes(out.String()), tc.output) {
+				t.Errorf("Expected %q inside the output %q", tc.output, out.String())
+			}
+		})
+	}
+}
+
+func TestMultidimSelectOnEnter(t *testing.T) {
+	items := []interface{}{"refresh", "broken", []interface{}{"a", "b"}, "quit"}
+
+	onEnter := func(item interface{}, index []int) (EnterAction, error) {
+		switch {
+		case item == "refresh":
+			return EnterAction{Kind: EnterReplace, Items: append(items, "new"), Message: "refreshed"}, nil
+		case item == "broken":
+			return EnterAction{}, errors.New("broken item")
+		case multidimlist.IsBranch(item):
+			return EnterAction{Kind: EnterDiveIn}, nil
+		case item == "b":
+			return EnterAction{Kind: EnterStay, Message: "b is busy"}, nil
+		}
+		return EnterAction{Kind: EnterExit}, nil
+	}
+
+	tcs := []struct {
+		name   string
+		input  string
+		index  []int
+		item   interface{}
+		output string
+	}{
+		{name: "when exiting", input: "jjj\r", index: []int{3}, item: "quit"},
+		{name: "when diving in", input: "jj\r\r", index: []int{2, 0}, item: "a"},
+		{name: "when replacing the items", input: "\rjjjj\r", index: []int{4}, item: "new", output: "refreshed"},
+		{name: "when failing", input: "j\rjj\r", index: []int{3}, item: "quit", output: "broken item"},
+		{name: "when showing a message", input: "jj\rj\rk\r", index: []int{2, 0}, item: "a", output: "b is busy"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout := &closeBuffer{}
+			s := MultidimSelect{
+				Label:   "Select Action",
+				Items:   items,
+				OnEnter: onEnter,
+				Stdin:   ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:  stdout,
+			}
+
+			index, item, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if !reflect.DeepEqual(index, tc.index) || item != tc.item {
+				t.Errorf("Expected %v at %v, got %v at %v", tc.item, tc.index, item, index)
+			}
+
+			if !strings.Contains(stripCodes(stdout.String()), tc.output) {
+				t.Errorf("Expected output to contain %q, got %q", tc.output, stripCodes(stdout.String()))
+			}
+		})
+	}
+
+	t.Run("when using an enter callback", func(t *testing.T) {
+		calls := 0
+		s := MultidimSelect{
+			Items: items,
+			EnterCallback: func(item interface{}, cursor []int) (bool, error) {
+				calls++
+				if item == "broken" {
+					return false, errors.New("broken item")
+				}
+				return calls > 1, nil
+			},
+			Stdin:  ioutil.NopCloser(strings.NewReader("\rj\r")),
+			Stdout: &closeBuffer{},
+		}
+
+		_, _, err := s.Run()
+		if err == nil || err.Error() != "broken item" {
+			t.Errorf("Expected the error of the callback, got %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("Expected the callback to be called twice, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,457 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chzyer/readline"
+	"github.com/lemotw/promptui/screenbuf"
+)
+
+// ErrPasswordMismatch is the error displayed when the confirmation of a password differs from the password.
+var ErrPasswordMismatch = errors.New("passwords don't match")
+
+// Password is a prompt asking for a password. The password is masked while it is typed, unless the reveal key
+// is pressed, and a strength meter is displayed below it. The password can be asked twice to confirm it.
+//
+// The typed keys never reach readline's line buffer. The password is kept in a byte slice which is zeroed once
+// it is no longer needed, and RunBytes returns it without converting it to a string.
+type Password struct {
+	// Label is the value displayed on the command line prompt.
+	Label interface{}
+
+	// ConfirmLabel is the value displayed when asking for the password again. The password is only asked
+	// once when it is nil.
+	ConfirmLabel interface{}
+
+	// Validate is an optional function that is used against the entered password to validate it. The
+	// password is asked again when it returns an error, which is displayed below the prompt.
+	Validate func(password []byte) error
+
+	// Strength is a function scoring the strength of the password from 0 to 4, displayed by the strength
+	// meter as it is typed. Defaults to a score based on the length of the password and on the kinds of
+	// characters it uses.
+	Strength func(password []byte) int
+
+	// Mask is the character displayed instead of the characters of the password. Defaults to "*".
+	Mask rune
+
+	// Reveal is the key showing the password instead of the mask until it is pressed again or the password
+	// is entered. Defaults to the ctrl+r key.
+	Reveal Key
+
+	// Templates can be used to customize the password output. If nil is passed, the default templates are
+	// used. See the PasswordTemplates docs for more info.
+	Templates *PasswordTemplates
+
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+
+	// HideEntered sets whether to hide the prompt after the user has pressed enter.
+	HideEntered bool
+
+	// HideStrength sets whether to hide the strength meter.
+	HideStrength bool
+}
+
+// PasswordStrength is the value given to the strength template of a Password.
+type PasswordStrength struct {
+	// Score is the strength of the password, from 0 to 4.
+	Score int
+
+	// Label describes the score, from "very weak" to "very strong".
+	Label string
+
+	// Bar is a meter of the score, like "■■□□".
+	Bar string
+}
+
+// PasswordTemplates allow a password prompt to be customized following stdlib text/template syntax. See the
+// PromptTemplates docs for more info on templates.
+type PasswordTemplates struct {
+	// Compiled templates
+	prompt     *template.Template
+	strength   *template.Template
+	validation *template.Template
+	success    *template.Template
+
+	// FuncMap is a map of helper functions that can be used inside of templates according to the text/template
+	// documentation.
+	//
+	// By default, FuncMap contains the color functions used to color the text in templates. If FuncMap
+	// is overridden, the colors functions must be added in the override from promptui.FuncMap to work.
+	FuncMap template.FuncMap
+
+	// Prompt is a text/template for the label displayed on the left side of the masked password. It receives
+	// the Label or the ConfirmLabel of the password.
+	Prompt string
+
+	// Strength is a text/template for the strength meter displayed below the password. It receives a
+	// PasswordStrength.
+	Strength string
+
+	// ValidationError is a text/template for the error returned by the validation function or for the
+	// ErrPasswordMismatch error.
+	ValidationError string
+
+	// Success is a text/template for the label once the password has been entered.
+	Success string
+}
+
+var strengthLabels = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+// Run executes the password prompt. It displays the label, asking the user to enter a password, and asks for
+// it again when ConfirmLabel is set until both entries match. It returns the password and an error if any
+// occurred during the prompt's execution.
+func (p *Password) Run() (string, error) {
+	password, err := p.RunBytes()
+	defer wipe(password)
+
+	return string(password), err
+}
+
+// RunBytes executes the password prompt like Run, returning the password as a byte slice. The caller should
+// zero the slice once the password is no longer needed.
+func (p *Password) RunBytes() ([]byte, error) {
+	err := p.prepareTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Mask == 0 {
+		p.Mask = '*'
+	}
+
+	if p.Reveal.Code == 0 {
+		p.Reveal = Key{Code: KeyReveal, Display: KeyRevealDisplay}
+	}
+
+	c := &readline.Config{
+		Stdin:          p.Stdin,
+		Stdout:         p.Stdout,
+		HistoryLimit:   -1,
+		UniqueEditLine: true,
+	}
+
+	err = c.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	rl, err := readline.NewEx(c)
+	if err != nil {
+		return nil, err
+	}
+
+	rl.Write([]byte(hideCursor))
+	sb := screenbuf.New(rl)
+
+	// password holds the first entry once it is confirmed, and input the entry being typed
+	var password, input []byte
+	var inputErr error
+	revealed := false
+
+	draw := func() {
+		label := p.Label
+		if password != nil {
+			label = p.ConfirmLabel
+		}
+
+		prompt := render(p.Templates.prompt, label)
+		if revealed {
+			prompt = append(prompt, input...)
+		} else {
+			prompt = append(prompt, strings.Repeat(string(p.Mask), utf8.RuneCount(input))...)
+		}
+		sb.Write(prompt)
+
+		if !p.HideStrength && password == nil {
+			sb.Write(render(p.Templates.strength, p.strength(input)))
+		}
+
+		if inputErr != nil {
+			sb.Write(render(p.Templates.validation, inputErr))
+		}
+
+		sb.Flush()
+	}
+
+	// mu guards the password, as readline keeps handling keys once a line ends
+	var mu sync.Mutex
+
+	// the password is typed here, so that readline only handles the keys ending the line
+	c.FuncFilterInputRune = func(key rune) (rune, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if key == 0 || stopsReading(key) {
+			return key, true
+		}
+
+		switch {
+		case key == p.Reveal.Code:
+			revealed = !revealed
+		case key == KeyBackspace || key == KeyCtrlH:
+			_, size := utf8.DecodeLastRune(input)
+			wipe(input[len(input)-size:])
+			input = input[:len(input)-size]
+		case unicode.IsPrint(key):
+			input = appendRune(input, key)
+			inputErr = nil
+		}
+
+		draw()
+		return key, false
+	}
+
+	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		draw()
+		return nil, 0, key != KeyEnter
+	})
+
+	for {
+		_, err = rl.Readline()
+		if err != nil {
+			break
+		}
+
+		mu.Lock()
+		done := false
+		inputErr = nil
+		if password == nil && p.Validate != nil {
+			inputErr = p.Validate(input)
+		}
+		switch {
+		case inputErr != nil:
+			wipe(input)
+			input = nil
+		case password == nil && p.ConfirmLabel != nil:
+			password, input = input, nil
+		case password == nil:
+			password, input, done = input, nil, true
+		case !bytes.Equal(password, input):
+			// both entries are asked again
+			wipe(password)
+			wipe(input)
+			password, input = nil, nil
+			inputErr = ErrPasswordMismatch
+		default:
+			wipe(input)
+			input, done = nil, true
+		}
+		revealed = false
+		mu.Unlock()
+
+		if done {
+			break
+		}
+	}
+
+	if err != nil {
+		mu.Lock()
+		wipe(password)
+		wipe(input)
+
+		switch err {
+		case readline.ErrInterrupt:
+			err = ErrInterrupt
+		case io.EOF:
+			err = ErrEOF
+		}
+		if err.Error() == "Interrupt" {
+			err = ErrInterrupt
+		}
+		sb.Reset()
+		sb.WriteString("")
+		sb.Flush()
+		mu.Unlock()
+		rl.Write([]byte(showCursor))
+		rl.Close()
+		return nil, err
+	}
+
+	mu.Lock()
+	if p.HideEntered {
+		clearScreen(sb)
+	} else {
+		sb.Reset()
+		prompt := render(p.Templates.success, p.Label)
+		prompt = append(prompt, strings.Repeat(string(p.Mask), utf8.RuneCount(password))...)
+		sb.Write(prompt)
+		sb.Flush()
+	}
+	mu.Unlock()
+
+	rl.Write([]byte(showCursor))
+	rl.Close()
+
+	return password, nil
+}
+
+// strength returns the strength of the given password for the strength meter.
+func (p *Password) strength(password []byte) *PasswordStrength {
+	var score int
+	if p.Strength != nil {
+		score = p.Strength(password)
+	} else {
+		score = strength(password)
+	}
+
+	max := len(strengthLabels) - 1
+	if score < 0 {
+		score = 0
+	}
+	if score > max {
+		score = max
+	}
+
+	return &PasswordStrength{
+		Score: score,
+		Label: strengthLabels[score],
+		Bar:   strings.Repeat("■", score) + strings.Repeat("□", max-score),
+	}
+}
+
+func (p *Password) prepareTemplates() error {
+	tpls := p.Templates
+	if tpls == nil {
+		tpls = &PasswordTemplates{}
+	}
+
+	if tpls.FuncMap == nil {
+		tpls.FuncMap = FuncMap
+	}
+
+	bold := Styler(FGBold)
+
+	if tpls.Prompt == "" {
+		tpls.Prompt = fmt.Sprintf("%s {{ . | bold }}%s ", bold(IconInitial), bold(":"))
+	}
+
+	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Prompt)
+	if err != nil {
+		return err
+	}
+
+	tpls.prompt = tpl
+
+	if tpls.Strength == "" {
+		tpls.Strength = `{{ if lt .Score 2 }}{{ .Bar | red }}{{ else if lt .Score 3 }}{{ .Bar | yellow }}` +
+			`{{ else }}{{ .Bar | green }}{{ end }} {{ .Label | faint }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Strength)
+	if err != nil {
+		return err
+	}
+
+	tpls.strength = tpl
+
+	if tpls.ValidationError == "" {
+		tpls.ValidationError = `{{ ">>" | red }} {{ . | red }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.ValidationError)
+	if err != nil {
+		return err
+	}
+
+	tpls.validation = tpl
+
+	if tpls.Success == "" {
+		tpls.Success = fmt.Sprintf("{{ . | faint }}%s ", Styler(FGFaint)(":"))
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Success)
+	if err != nil {
+		return err
+	}
+
+	tpls.success = tpl
+
+	p.Templates = tpls
+
+	return nil
+}
+
+// strength scores a password from 0 to 4, a point being given for a length of at least 8 and of at least 12
+// characters and for each kind of characters used beyond the first one, among lower case and upper case
+// letters, digits and symbols.
+func strength(password []byte) int {
+	var lower, upper, digit, symbol bool
+	count := 0
+
+	for len(password) > 0 {
+		r, size := utf8.DecodeRune(password)
+		password = password[size:]
+		count++
+
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	score := 0
+	if count >= 8 {
+		score++
+	}
+	if count >= 12 {
+		score++
+	}
+
+	kinds := 0
+	for _, used := range []bool{lower, upper, digit, symbol} {
+		if used {
+			kinds++
+		}
+	}
+	if kinds > 1 {
+		score += kinds - 1
+	}
+
+	if score > 4 {
+		score = 4
+	}
+
+	return score
+}
+
+// appendRune appends the rune to the password, zeroing the previous array when it has to grow.
+func appendRune(password []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+
+	if len(password)+n > cap(password) {
+		grown := make([]byte, len(password), 2*cap(password)+n)
+		copy(grown, password)
+		wipe(password)
+		password = grown
+	}
+
+	return append(password, buf[:n]...)
+}
+
+// wipe zeroes the given bytes.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
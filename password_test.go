@@ -0,0 +1,128 @@
+package promptui
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestPasswordRun(t *testing.T) {
+	short := func(password []byte) error {
+		if len(password) < 4 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+
+	tcs := []struct {
+		name     string
+		password Password
+		input    string
+		expect   string
+		output   string
+		hidden   string
+	}{
+		{name: "when entering a password", input: "secret\r", expect: "secret", output: "Password: ******", hidden: "secret"},
+		{name: "when deleting characters", input: "secrex\x7ft\r", expect: "secret"},
+		{name: "when entering unicode", input: "pässwörd\r", expect: "pässwörd", output: "********"},
+		{name: "when using a mask", password: Password{Mask: '•'}, input: "abc\r", expect: "abc", output: "•••"},
+		{name: "when revealing", input: "ab\x12c\r", expect: "abc", output: "Password: abc"},
+		{name: "when validating", password: Password{Validate: short}, input: "abc\rabcd\r", expect: "abcd", output: ">> too short"},
+		{
+			name:     "when confirming",
+			password: Password{ConfirmLabel: "Again"},
+			input:    "secret\rsecret\r",
+			expect:   "secret",
+			output:   "Again: ******",
+		},
+		{
+			name:     "when the confirmation differs",
+			password: Password{ConfirmLabel: "Again"},
+			input:    "secret\rsecrets\rother\rother\r",
+			expect:   "other",
+			output:   ">> passwords don't match",
+		},
+		{name: "when weak", input: "abc\r", expect: "abc", output: "□□□□ very weak"},
+		{name: "when strong", input: "Correct-Horse9\r", expect: "Correct-Horse9", output: "■■■■ very strong"},
+		{name: "when hiding the strength", password: Password{HideStrength: true}, input: "abc\r", expect: "abc", hidden: "weak"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			p := tc.password
+			p.Label = "Password"
+			p.Stdin = ioutil.NopCloser(strings.NewReader(tc.input))
+			p.Stdout = out
+
+			password, err := p.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running password %v", err)
+			}
+
+			if password != tc.expect {
+				t.Errorf("Expected the password %q, got %q", tc.expect, password)
+			}
+
+			output := stripCodes(out.String())
+			if !strings.Contains(output, tc.output) {
+				t.Errorf("Expected %q inside the output %q", tc.output, output)
+			}
+
+			if tc.hidden != "" && strings.Contains(output, tc.hidden) {
+				t.Errorf("Expected %q to be hidden from the output %q", tc.hidden, output)
+			}
+		})
+	}
+
+	t.Run("when canceled", func(t *testing.T) {
+		p := Password{
+			Label:  "Password",
+			Stdin:  ioutil.NopCloser(strings.NewReader("abc\x03")),
+			Stdout: &closeBuffer{},
+		}
+
+		_, err := p.RunBytes()
+		if err != ErrInterrupt {
+			t.Errorf("Expected ErrInterrupt, got %v", err)
+		}
+	})
+}
+
+func TestPasswordStrength(t *testing.T) {
+	tcs := []struct {
+		password string
+		expect   int
+	}{
+		{password: "", expect: 0},
+		{password: "abcdefgh", expect: 1},
+		{password: "abcdefgh1", expect: 2},
+		{password: "abcdefghijk1", expect: 3},
+		{password: "Abcdefghijk1!", expect: 4},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.password, func(t *testing.T) {
+			got := strength([]byte(tc.password))
+			if got != tc.expect {
+				t.Errorf("Expected a strength of %d, got %d", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestAppendRune(t *testing.T) {
+	password := make([]byte, 0, 1)
+	password = appendRune(password, 'a')
+	old := password
+
+	password = appendRune(password, 'ö')
+	if string(password) != "aö" {
+		t.Errorf("Expected the password %q, got %q", "aö", password)
+	}
+
+	if old[0] != 0 {
+		t.Errorf("Expected the previous buffer to be zeroed, got %q", old)
+	}
+}
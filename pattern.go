@@ -0,0 +1,126 @@
+package promptui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// patternSlots are the runes of an input pattern standing for a character typed by the user, along with the
+// characters they accept.
+var patternSlots = map[rune]func(r rune) bool{
+	'#': unicode.IsDigit,
+	'9': unicode.IsDigit,
+	'a': unicode.IsLetter,
+	'*': func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+}
+
+// pattern is an input pattern, like "###-###-####". Each of its runes is either a slot accepting some
+// characters typed by the user or a literal character inserted as the slots are filled.
+//
+// The "#" and "9" slots accept a digit, the "a" slot a letter and the "*" slot a letter or a digit. Any other
+// character is a literal, and a backslash makes the character following it a literal too.
+type pattern []patternRune
+
+type patternRune struct {
+	r    rune
+	slot bool
+}
+
+// parsePattern returns the pattern written in the given string.
+func parsePattern(s string) pattern {
+	var p pattern
+
+	escaped := false
+	for _, r := range s {
+		_, slot := patternSlots[r]
+
+		switch {
+		case escaped:
+			p = append(p, patternRune{r: r})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			p = append(p, patternRune{r: r, slot: slot})
+		}
+	}
+
+	return p
+}
+
+// accepts returns whether the rune can fill the slot at the given position.
+func (p pattern) accepts(i int, r rune) bool {
+	return i < len(p) && p[i].slot && patternSlots[p[i].r](r)
+}
+
+// literal returns whether the rune at the given position is a literal.
+func (p pattern) literal(i int) bool {
+	return i < len(p) && !p[i].slot
+}
+
+// slots returns the runes of the input filling the slots of the pattern.
+func (p pattern) slots(input []rune) []rune {
+	var runes []rune
+	for i, r := range input {
+		if i < len(p) && p[i].slot {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// format places the runes in the slots of the pattern, along with the literals preceding them. It stops at
+// the first rune the slot of which does not accept it.
+func (p pattern) format(runes []rune) []rune {
+	var input []rune
+
+	for i, pr := range p {
+		if len(runes) == 0 || (pr.slot && !p.accepts(i, runes[0])) {
+			break
+		}
+
+		if pr.slot {
+			input = append(input, runes[0])
+			runes = runes[1:]
+		} else {
+			input = append(input, pr.r)
+		}
+	}
+
+	return input
+}
+
+// position returns the position of the slot at the given index, or the length of the pattern past its last
+// slot.
+func (p pattern) position(index int) int {
+	for i, pr := range p {
+		if !pr.slot {
+			continue
+		}
+		if index == 0 {
+			return i
+		}
+		index--
+	}
+	return len(p)
+}
+
+// size returns the number of slots of the pattern.
+func (p pattern) size() int {
+	size := 0
+	for _, pr := range p {
+		if pr.slot {
+			size++
+		}
+	}
+	return size
+}
+
+// String returns the pattern without the backslashes escaping its literals.
+func (p pattern) String() string {
+	var b strings.Builder
+	for _, pr := range p {
+		b.WriteRune(pr.r)
+	}
+	return b.String()
+}
@@ -0,0 +1,198 @@
+package promptui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PreviewFunc is a function returning the preview of an item, displayed inside the preview pane of a select.
+type PreviewFunc func(item interface{}) (string, error)
+
+// PreviewPosition defines where the preview pane of a select is displayed.
+type PreviewPosition int
+
+const (
+	// PreviewBottom displays the preview pane below the list and its details.
+	PreviewBottom PreviewPosition = iota
+
+	// PreviewSide displays the preview pane on the right of the list.
+	PreviewSide
+)
+
+// preview is the result of the Preview function for an item, done telling whether the function returned.
+type preview struct {
+	text string
+	err  error
+	done bool
+}
+
+// hasPreview returns whether the select displays a preview pane.
+func (s *Select) hasPreview() bool {
+	return s.Preview != nil || (s.Templates != nil && s.Templates.preview != nil)
+}
+
+// previewUpKey returns the key scrolling the preview pane up, ctrl+y being used when the keys don't set any.
+func (s *Select) previewUpKey() Key {
+	if s.Keys.PreviewUp.Code == 0 {
+		return Key{Code: KeyPreviewUp, Display: KeyPreviewUpDisplay}
+	}
+	return s.Keys.PreviewUp
+}
+
+// previewDownKey returns the key scrolling the preview pane down, ctrl+e being used when the keys don't set
+// any.
+func (s *Select) previewDownKey() Key {
+	if s.Keys.PreviewDown.Code == 0 {
+		return Key{Code: KeyPreviewDown, Display: KeyPreviewDownDisplay}
+	}
+	return s.Keys.PreviewDown
+}
+
+// scrollPreview scrolls the preview pane by the given number of lines. It is kept inside the preview when the
+// pane is rendered.
+func (s *Select) scrollPreview(lines int) {
+	s.previewTop += lines
+	if s.previewTop < 0 {
+		s.previewTop = 0
+	}
+}
+
+// previewLines returns the lines of the preview of the given item. The Preview function is started in the
+// background when its result is not cached yet, the select being drawn again once it returns. Only one
+// preview is loaded at a time: the items which are passed over in the meantime are not loaded, the preview
+// of the item active once it returns being loaded next.
+func (s *Select) previewLines(item interface{}) []string {
+	if s.Preview == nil {
+		output := bytes.TrimRight(render(s.Templates.preview, item), "\n")
+		return strings.Split(string(output), "\n")
+	}
+
+	key := previewKey(item)
+
+	p, ok := s.previews[key]
+	if !ok && !s.previewing {
+		if s.previews == nil {
+			s.previews = make(map[string]*preview)
+		}
+
+		p = &preview{}
+		s.previews[key] = p
+		s.previewing = true
+
+		go func() {
+			text, err := s.Preview(item)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			p.text, p.err, p.done = text, err, true
+			s.previewing = false
+			if s.redraw != nil {
+				s.redraw()
+			}
+		}()
+	}
+
+	switch {
+	case p == nil || !p.done:
+		return []string{Styler(FGFaint)("Loading...")}
+	case p.err != nil:
+		return []string{fmt.Sprintf("%s %v", IconBad, p.err)}
+	}
+
+	return strings.Split(strings.TrimRight(p.text, "\n"), "\n")
+}
+
+// previewPane renders the lines of the preview pane of the given item, which is nil when no item is active.
+// The pane is always the given number of lines high and starts with a gutter showing whether it can be
+// scrolled.
+func (s *Select) previewPane(item interface{}, height int) [][]byte {
+	var lines []string
+
+	if item != nil {
+		if key := previewKey(item); key != s.previewed {
+			s.previewed = key
+			s.previewTop = 0
+		}
+		lines = s.previewLines(item)
+	}
+
+	if max := len(lines) - height; s.previewTop > max {
+		s.previewTop = max
+	}
+	if s.previewTop < 0 {
+		s.previewTop = 0
+	}
+
+	pane := make([][]byte, height)
+	for i := range pane {
+		gutter := "│"
+		switch {
+		case i == 0 && s.previewTop > 0:
+			gutter = "↑"
+		case i == height-1 && s.previewTop+height < len(lines):
+			gutter = "↓"
+		}
+
+		line := ""
+		if j := s.previewTop + i; j < len(lines) {
+			line = lines[j]
+		}
+
+		// the pane below the list lines up with its items
+		indent := "  "
+		if s.PreviewPosition == PreviewSide {
+			indent = ""
+			if size := s.previewSize(); width(line) > size {
+				line = cut(stripCodes(line), size)
+			}
+		}
+
+		pane[i] = []byte(indent + Styler(FGFaint)(gutter) + " " + line)
+	}
+
+	return pane
+}
+
+// previewSize returns the height or the width of the preview pane, as set by PreviewSize.
+func (s *Select) previewSize() int {
+	switch {
+	case s.PreviewSize > 0:
+		return s.PreviewSize
+	case s.PreviewPosition == PreviewSide:
+		return 40
+	}
+	return 5
+}
+
+// besides joins the rows of the list and the lines of the preview pane, aligning the pane after the widest
+// row. Rows are added when the pane is higher than the list.
+func besides(rows, pane [][]byte) [][]byte {
+	size := 0
+	for _, row := range rows {
+		if w := width(string(row)); w > size {
+			size = w
+		}
+	}
+
+	for len(rows) < len(pane) {
+		rows = append(rows, nil)
+	}
+
+	result := make([][]byte, len(rows))
+	for i, row := range rows {
+		result[i] = append([]byte{}, row...)
+		if i < len(pane) {
+			result[i] = append(result[i], strings.Repeat(" ", size-width(string(row))+2)...)
+			result[i] = append(result[i], pane[i]...)
+		}
+	}
+
+	return result
+}
+
+// previewKey returns the key of the given item inside the cache of the previews.
+func previewKey(item interface{}) string {
+	return fmt.Sprintf("%T %#v", item, item)
+}
@@ -0,0 +1,178 @@
+package promptui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectPreview(t *testing.T) {
+	items := []string{"one", "two"}
+	tpl := "{{ . }} line 1\n{{ . }} line 2\n{{ . }} line 3"
+
+	tcs := []struct {
+		name     string
+		position PreviewPosition
+		input    string
+		lines    [][]string
+	}{
+		{
+			name:  "below the list",
+			input: "\r",
+			lines: [][]string{{"│ one line 1"}, {"↓ one line 2"}},
+		},
+		{
+			name:  "scrolls the pane",
+			input: "\x05\x05\x05\r",
+			lines: [][]string{{"↑ one line 2"}, {"│ one line 3"}},
+		},
+		{
+			name:  "scrolls the pane back",
+			input: "\x05\x19\r",
+			lines: [][]string{{"│ one line 1"}, {"↓ one line 2"}},
+		},
+		{
+			name:  "resets the scroll of another item",
+			input: "\x05\x0e\r",
+			lines: [][]string{{"│ two line 1"}},
+		},
+		{
+			name:     "beside the list",
+			position: PreviewSide,
+			input:    "\r",
+			lines:    [][]string{{"▸ one", "│ one li…"}, {"  two", "↓ one li…"}},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			s := Select{
+				Label:           "Number",
+				Items:           items,
+				Templates:       &SelectTemplates{Preview: tpl},
+				PreviewPosition: tc.position,
+				PreviewSize:     2,
+				Size:            2,
+				Stdin:           ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:          out,
+			}
+
+			if tc.position == PreviewSide {
+				s.PreviewSize = 7
+			}
+
+			_, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			// the last frame before the selection holds the expected lines
+			frames := strings.Split(stripCodes(out.String()), "Use the arrow keys")
+			lines := strings.Split(frames[len(frames)-1], "\r")
+
+			for _, parts := range tc.lines {
+				if !containsLine(lines, parts...) {
+					t.Errorf("Expected a line with %q inside the output %q", parts, lines)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectPreviewFunc(t *testing.T) {
+	r, w := io.Pipe()
+
+	called := make(chan interface{}, 10)
+	release := make(chan struct{})
+
+	out := &closeBuffer{}
+	s := &Select{
+		Label: "File",
+		Items: []string{"notes.txt", "todo.txt", "readme.txt", "missing.txt"},
+		Preview: func(item interface{}) (string, error) {
+			called <- item
+			<-release
+
+			if item == "missing.txt" {
+				return "", errors.New("file not found")
+			}
+			return fmt.Sprintf("contents of %s", item), nil
+		},
+		Stdin:  r,
+		Stdout: out,
+	}
+
+	done := make(chan error)
+	go func() {
+		_, _, err := s.Run()
+		done <- err
+	}()
+
+	expectCall := func(item string) {
+		select {
+		case got := <-called:
+			if got != item {
+				t.Fatalf("Expected the preview of %s, got %v", item, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected the preview of %s", item)
+		}
+	}
+
+	output := func() string {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return stripCodes(out.String())
+	}
+
+	waitFor := func(text string) {
+		for i := 0; i < 100 && !strings.Contains(output(), text); i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if !strings.Contains(output(), text) {
+			t.Fatalf("Expected the output to contain %q, got %q", text, output())
+		}
+	}
+
+	expectCall("notes.txt")
+
+	// the items passed over while a preview is pending are not loaded
+	w.Write([]byte("\x0e\x0e\x0e"))
+	waitFor("▸ missing.txt")
+
+	if !strings.Contains(output(), "Loading...") {
+		t.Errorf("Expected the preview to be loading, got %q", output())
+	}
+
+	release <- struct{}{}
+	expectCall("missing.txt")
+
+	release <- struct{}{}
+	waitFor("✗ file not found")
+
+	// the previews are cached
+	w.Write([]byte("\x10\x10\x10"))
+	expectCall("readme.txt")
+	waitFor("│ contents of notes.txt")
+
+	release <- struct{}{}
+	w.Write([]byte("\r"))
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error running select %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the select to end")
+	}
+
+	if len(called) != 0 {
+		t.Errorf("Expected the previews to be cached, got %d more calls", len(called))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/chzyer/readline"
@@ -33,9 +34,27 @@ type Prompt struct {
 	Default string
 
 	// Mask is an optional rune that sets which character to display instead of the entered characters. This
-	// allows hiding private information like passwords.
+	// allows hiding private information like passwords. Password also confirms passwords and keeps them out
+	// of strings.
 	Mask rune
 
+	// Pattern is an optional input pattern, like "###-###-####" or "9999-99-99", that the entered value must
+	// follow. Only the characters accepted at the place of the cursor can be typed, the other characters of the
+	// pattern being inserted as the value is typed. The value is invalid until the pattern is complete. See
+	// Cursor.SetPattern for the characters of a pattern.
+	Pattern string
+
+	// AllowedRunes is an optional function restricting the characters that can be typed to the ones it returns
+	// true for, like unicode.IsDigit. A hint is displayed when a character is rejected.
+	AllowedRunes func(r rune) bool
+
+	// MaxLength is the maximum number of characters that can be typed. There is no limit when zero.
+	MaxLength int
+
+	// Transform is an optional function changing the value each time characters are typed, like strings.ToLower
+	// to force lower case.
+	Transform func(input string) string
+
 	// AllowEdit lets the user edit the default value. If false, any key press
 	// other than <Enter> automatically clears the default value.
 	AllowEdit bool
@@ -43,7 +62,8 @@ type Prompt struct {
 	HideEntered bool
 
 	// IsConfirm makes the prompt ask for a yes or no ([Y/N]) question rather than request an input. When set,
-	// most properties related to input will be ignored.
+	// most properties related to input will be ignored. Confirm asks such questions returning the answer as a
+	// boolean instead.
 	IsConfirm bool
 
 	// IsVimMode enables vi-like movements (hjkl) and editing.
@@ -79,6 +99,7 @@ type PromptTemplates struct {
 	invalid    *template.Template
 	validation *template.Template
 	success    *template.Template
+	rejected   *template.Template
 	// FuncMap is a map of helper functions that can be used inside of templates according to the text/template
 	// documentation.
 	//
@@ -101,6 +122,9 @@ type PromptTemplates struct {
 	// Prompt is a text/template for the prompt label when the value is invalid due to an error triggered by
 	// the prompt's validation function.
 	ValidationError string
+	// Rejected is a text/template for the hint displayed when a typed character is rejected by the AllowedRunes,
+	// the MaxLength or the Pattern of the prompt. It receives the error telling why.
+	Rejected string
 }
 
 // Run executes the prompt. Its displays the label and default value if any, asking the user to enter a value.
@@ -152,7 +176,31 @@ func (p *Prompt) Run() (string, error) {
 	eraseDefault := input != "" && !p.AllowEdit
 	cur := NewCursor(input, p.Pointer, eraseDefault)
 
+	if !p.IsConfirm {
+		cur.AllowedRunes = p.AllowedRunes
+		cur.MaxLength = p.MaxLength
+		cur.Transform = p.Transform
+	}
+
+	if p.Pattern != "" && !p.IsConfirm {
+		cur.SetPattern(p.Pattern)
+
+		validate := validFn
+		validFn = func(x string) error {
+			if !cur.complete() {
+				return fmt.Errorf("enter a value like %s", cur.pattern)
+			}
+			return validate(x)
+		}
+	}
+
+	// mu guards the input, as readline keeps handling keys once a line ends
+	var mu sync.Mutex
+
 	listen := func(input []rune, pos int, key rune) ([]rune, int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
 		_, _, keepOn := cur.Listen(input, pos, key)
 		err := validFn(cur.Get())
 		var prompt []byte
@@ -179,6 +227,9 @@ func (p *Prompt) Run() (string, error) {
 			sb.Write(validation)
 			inputErr = nil
 		}
+		if rejected := cur.Rejected(); rejected != nil {
+			sb.Write(render(p.Templates.rejected, rejected))
+		}
 		sb.Flush()
 		return nil, 0, keepOn
 	}
@@ -187,7 +238,9 @@ func (p *Prompt) Run() (string, error) {
 
 	for {
 		_, err = rl.Readline()
+		mu.Lock()
 		inputErr = validFn(cur.Get())
+		mu.Unlock()
 		if inputErr == nil {
 			break
 		}
@@ -207,15 +260,20 @@ func (p *Prompt) Run() (string, error) {
 		if err.Error() == "Interrupt" {
 			err = ErrInterrupt
 		}
+		mu.Lock()
 		sb.Reset()
 		sb.WriteString("")
 		sb.Flush()
+		mu.Unlock()
 		rl.Write([]byte(showCursor))
 		rl.Close()
 		return "", err
 	}
 
-	echo := cur.Get()
+	mu.Lock()
+	value := cur.Get()
+
+	echo := value
 	if p.Mask != 0 {
 		echo = cur.GetMask(p.Mask)
 	}
@@ -225,7 +283,7 @@ func (p *Prompt) Run() (string, error) {
 
 	if p.IsConfirm {
 		lowerDefault := strings.ToLower(p.Default)
-		if strings.ToLower(cur.Get()) != "y" && (lowerDefault != "y" || (lowerDefault == "y" && cur.Get() != "")) {
+		if strings.ToLower(value) != "y" && (lowerDefault != "y" || (lowerDefault == "y" && value != "")) {
 			prompt = render(p.Templates.invalid, p.Label)
 			err = ErrAbort
 		}
@@ -238,11 +296,12 @@ func (p *Prompt) Run() (string, error) {
 		sb.Write(prompt)
 		sb.Flush()
 	}
+	mu.Unlock()
 
 	rl.Write([]byte(showCursor))
 	rl.Close()
 
-	return cur.Get(), err
+	return value, err
 }
 
 func (p *Prompt) prepareTemplates() error {
@@ -318,6 +377,17 @@ func (p *Prompt) prepareTemplates() error {
 
 	tpls.validation = tpl
 
+	if tpls.Rejected == "" {
+		tpls.Rejected = `{{ ">>" | yellow }} {{ . | faint }}`
+	}
+
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Rejected)
+	if err != nil {
+		return err
+	}
+
+	tpls.rejected = tpl
+
 	if tpls.Success == "" {
 		tpls.Success = fmt.Sprintf("{{ . | faint }}%s ", Styler(FGFaint)(":"))
 	}
@@ -0,0 +1,89 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestPromptPattern(t *testing.T) {
+	tcs := []struct {
+		name   string
+		prompt Prompt
+		input  string
+		expect string
+		output string
+	}{
+		{name: "when typing the pattern", input: "555x1234567\r", expect: "555-123-4567", output: "Phone: 555-123-4567"},
+		{name: "when incomplete", input: "555\r1234567\r", expect: "555-123-4567", output: ">> enter a value like ###-###-####"},
+		{name: "when formatting the default", prompt: Prompt{Default: "5551234567", AllowEdit: true}, input: "\r", expect: "555-123-4567"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			p := tc.prompt
+			p.Label = "Phone"
+			p.Pattern = "###-###-####"
+			p.Stdin = ioutil.NopCloser(strings.NewReader(tc.input))
+			p.Stdout = out
+
+			result, err := p.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running prompt %v", err)
+			}
+
+			if result != tc.expect {
+				t.Errorf("Expected the value %q, got %q", tc.expect, result)
+			}
+
+			if !strings.Contains(stripCodes(out.String()), tc.output) {
+				t.Errorf("Expected %q inside the output %q", tc.output, out.String())
+			}
+		})
+	}
+}
+
+func TestPromptFilter(t *testing.T) {
+	tcs := []struct {
+		name   string
+		prompt Prompt
+		input  string
+		expect string
+		output string
+	}{
+		{
+			name:   "when typing a rune not allowed",
+			prompt: Prompt{AllowedRunes: unicode.IsLetter},
+			input:  "ab1\r",
+			expect: "ab",
+			output: ">> this character is not allowed",
+		},
+		{name: "when typing past the max length", prompt: Prompt{MaxLength: 2}, input: "abc\r", expect: "ab", output: ">> the value is limited to 2 characters"},
+		{name: "when transforming the value", prompt: Prompt{Transform: strings.ToUpper}, input: "abc\r", expect: "ABC", output: "Name: ABC"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			p := tc.prompt
+			p.Label = "Name"
+			p.Stdin = ioutil.NopCloser(strings.NewReader(tc.input))
+			p.Stdout = out
+
+			result, err := p.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running prompt %v", err)
+			}
+
+			if result != tc.expect {
+				t.Errorf("Expected the value %q, got %q", tc.expect, result)
+			}
+
+			if !strings.Contains(stripCodes(out.String()), tc.output) {
+				t.Errorf("Expected %q inside the output %q", tc.output, out.String())
+			}
+		})
+	}
+}
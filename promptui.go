@@ -22,6 +22,9 @@ var ErrInterrupt = errors.New("^C")
 // ErrAbort is the error returned when confirm prompts are supplied "n"
 var ErrAbort = errors.New("")
 
+// ErrNotRunning is the error returned when changing the items of a select that is not running.
+var ErrNotRunning = errors.New("select is not running")
+
 // ValidateFunc is a placeholder type for any validation functions that validates a given input. It should return
 // a ValidationError if the input is not valid.
 type ValidateFunc func(string) error
@@ -0,0 +1,216 @@
+package promptui
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/lemotw/promptui/list"
+)
+
+// Reorder is a prompt letting the user change the order of a list of items, for instance to prioritize
+// them. An item is grabbed with a key and moved up and down with the arrow keys until it is dropped again.
+// The new order is confirmed by pressing enter.
+type Reorder struct {
+	// Label is the text displayed on top of the list to direct input. The IconInitial value "?" will be
+	// appended automatically to the label so it does not need to be added.
+	Label interface{}
+
+	// Items are the items to reorder. It expects a slice of any kind of values, like Select.Items.
+	Items interface{}
+
+	// Templates can be used to customize the reorder output. If nil is passed, the default templates are
+	// used. See the ReorderTemplates docs for more info.
+	Templates *ReorderTemplates
+	// Keys is the set of keys used to control the interface. See the ReorderKeys docs for more info.
+	Keys *ReorderKeys
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+	// A function that determines how to render the cursor
+	Pointer Pointer
+
+	// Size is the number of items that should appear before scrolling is necessary. Defaults to 5.
+	Size int
+
+	// IsVimMode sets whether to use vim mode when using readline in the command prompt.
+	IsVimMode bool
+	// HideHelp sets whether to hide help information.
+	HideHelp bool
+	// HideSelected sets whether to hide the text displayed after the order is confirmed.
+	HideSelected bool
+}
+
+// ReorderKeys defines the available keys used by a reorder prompt. It extends the SelectKeys with the keys
+// used to move the items. The Next and Prev keys move the grabbed item instead of the cursor.
+type ReorderKeys struct {
+	SelectKeys
+
+	// Grab is the key used to grab the active item or to drop the grabbed one. Defaults to the space key.
+	Grab Key
+
+	// MoveUp is the key used to move the active item up without grabbing it. Defaults to "K", as terminals
+	// don't report the shift+up key to readline apart from the up key.
+	MoveUp Key
+
+	// MoveDown is the key used to move the active item down without grabbing it. Defaults to "J".
+	MoveDown Key
+}
+
+// ReorderTemplates allow a reorder prompt to be customized. The SelectTemplates are used for the items,
+// the Selected template receiving the active item once the order is confirmed.
+type ReorderTemplates struct {
+	SelectTemplates
+
+	grabbed *template.Template
+
+	// Grabbed is a text/template for the item while it is grabbed. Defaults to the active item followed by
+	// a move marker.
+	Grabbed string
+}
+
+// Run executes the reorder prompt. It displays the label and the list of items, letting the user move them
+// until the order is confirmed. It returns the new order as indices into Items, the first index being the
+// original index of the item now first, and an error if any occurred during the prompt's execution.
+func (r *Reorder) Run() ([]int, error) {
+	items, err := itemsOf(r.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Size == 0 {
+		r.Size = 5
+	}
+
+	r.setKeys()
+
+	err = r.prepareTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	l, err := list.New(items, r.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Select{
+		Label:        r.Label,
+		Templates:    &r.Templates.SelectTemplates,
+		Keys:         &r.Keys.SelectKeys,
+		Stdin:        r.Stdin,
+		Stdout:       r.Stdout,
+		Pointer:      r.Pointer,
+		Size:         r.Size,
+		IsVimMode:    r.IsVimMode,
+		HideHelp:     r.HideHelp,
+		HideSelected: r.HideSelected,
+		list:         l,
+	}
+	l.Labeler = s.labeler()
+
+	grabbed := false
+
+	// move keeps the order in step with the items of the list
+	move := func(up bool) {
+		i := l.Index()
+
+		moved := false
+		if up {
+			moved = l.MoveUp()
+		} else {
+			moved = l.MoveDown()
+		}
+
+		if moved {
+			j := l.Index()
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	s.onKey = func(key rune, term string, searching bool) bool {
+		switch {
+		case key == r.Keys.Grab.Code:
+			grabbed = !grabbed
+		case key == r.Keys.MoveUp.Code, grabbed && (key == r.Keys.Prev.Code || key == 'k'):
+			move(true)
+		case key == r.Keys.MoveDown.Code, grabbed && (key == r.Keys.Next.Code || key == 'j'):
+			move(false)
+		default:
+			return false
+		}
+
+		return true
+	}
+
+	s.renderRow = func(item interface{}, active bool) ([]byte, bool) {
+		if !active || !grabbed {
+			return nil, false
+		}
+		return render(r.Templates.grabbed, item), true
+	}
+
+	_, _, err = s.innerRun(0, 0, ' ')
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *Reorder) setKeys() {
+	if r.Keys != nil {
+		return
+	}
+
+	s := &Select{}
+	s.setKeys()
+
+	r.Keys = &ReorderKeys{
+		SelectKeys: *s.Keys,
+		Grab:       Key{Code: ' ', Display: "space"},
+		MoveUp:     Key{Code: 'K', Display: "K"},
+		MoveDown:   Key{Code: 'J', Display: "J"},
+	}
+}
+
+func (r *Reorder) prepareTemplates() error {
+	tpls := r.Templates
+	if tpls == nil {
+		tpls = &ReorderTemplates{}
+	}
+
+	if tpls.Help == "" {
+		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} `+
+			`{{ .PrevKey | faint }} {{ "%s" | faint }} {{ "grabs an item and" | faint }} `+
+			`{{ "%s" | faint }} {{ "%s" | faint }} {{ "move it" | faint }}`,
+			r.Keys.Grab.Display, r.Keys.MoveDown.Display, r.Keys.MoveUp.Display)
+	}
+
+	s := &Select{Templates: &tpls.SelectTemplates}
+
+	err := s.prepareTemplates()
+	if err != nil {
+		return err
+	}
+
+	if tpls.Grabbed == "" {
+		tpls.Grabbed = fmt.Sprintf(`%s {{ . | bold | cyan }} {{ "↕" | faint }}`, IconSelect)
+	}
+
+	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Grabbed)
+	if err != nil {
+		return err
+	}
+
+	tpls.grabbed = tpl
+
+	r.Templates = tpls
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReorderRun(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f"}
+
+	tcs := []struct {
+		name   string
+		input  string
+		expect []int
+	}{
+		{name: "keeps the order", input: "\r", expect: []int{0, 1, 2, 3, 4, 5}},
+		{name: "moves a grabbed item down", input: " \x0e\x0e\r", expect: []int{1, 2, 0, 3, 4, 5}},
+		{name: "drops the grabbed item", input: " \x0e \x0e\x0e \x10\r", expect: []int{1, 0, 3, 2, 4, 5}},
+		{name: "moves with the move keys", input: "\x0e\x0eKK\r", expect: []int{2, 0, 1, 3, 4, 5}},
+		{name: "moves past the page", input: "JJJJJJJ\r", expect: []int{1, 2, 3, 4, 5, 0}},
+		{name: "moves with vim keys", input: "jj kk \r", expect: []int{2, 0, 1, 3, 4, 5}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Reorder{
+				Label:  "Priority",
+				Items:  items,
+				Stdin:  ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout: &closeBuffer{},
+			}
+
+			order, err := r.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running reorder %v", err)
+			}
+
+			if !reflect.DeepEqual(order, tc.expect) {
+				t.Errorf("Expected order %v, got %v", tc.expect, order)
+			}
+		})
+	}
+
+	t.Run("renders the grabbed item", func(t *testing.T) {
+		out := &closeBuffer{}
+		r := Reorder{
+			Label:     "Priority",
+			Items:     items,
+			Templates: &ReorderTemplates{Grabbed: "moving {{ . }}"},
+			Stdin:     ioutil.NopCloser(strings.NewReader(" \x0e\r")),
+			Stdout:    out,
+		}
+
+		_, err := r.Run()
+		if err != nil {
+			t.Fatalf("Unexpected error running reorder %v", err)
+		}
+
+		if !strings.Contains(stripCodes(out.String()), "moving a") {
+			t.Errorf("Expected the grabbed item to be rendered, got %q", out.String())
+		}
+	})
+
+	t.Run("when items are not a slice", func(t *testing.T) {
+		r := Reorder{Items: "a"}
+
+		_, err := r.Run()
+		if err == nil {
+			t.Errorf("Expected an error for items %v", r.Items)
+		}
+	})
+}
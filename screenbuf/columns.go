@@ -0,0 +1,101 @@
+package screenbuf
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/chzyer/readline"
+)
+
+var resetCode = []byte(esc + "0m")
+
+// WriteColumns writes the given columns side by side, one line of the underlining buffer per row. Each
+// column is padded or truncated to its width, counted in terminal cells without the escape codes, and the
+// columns are separated by sep. The number of lines written is the number of rows of the longest column.
+func (s *ScreenBuf) WriteColumns(widths []int, sep string, columns ...[][]byte) error {
+	if len(widths) != len(columns) {
+		return fmt.Errorf("%d widths given for %d columns", len(widths), len(columns))
+	}
+
+	height := 0
+	for _, column := range columns {
+		if len(column) > height {
+			height = len(column)
+		}
+	}
+
+	for i := 0; i < height; i++ {
+		var line []byte
+
+		for j, column := range columns {
+			if j > 0 {
+				line = append(line, sep...)
+			}
+
+			var cell []byte
+			if i < len(column) {
+				cell = column[i]
+			}
+
+			line = append(line, fit(cell, widths[j])...)
+		}
+
+		_, err := s.Write(line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fit pads or truncates b to the given width, keeping its escape codes. Truncated styled text is reset so
+// that its style does not spread to the next column.
+func fit(b []byte, width int) []byte {
+	var out []byte
+	styled, truncated := false, false
+	w := 0
+
+	for i := 0; i < len(b); {
+		if b[i] == esc[0] {
+			// an escape code ends with its first letter
+			j := i + 1
+			for j < len(b) && !isLetter(b[j]) {
+				j++
+			}
+			if j < len(b) {
+				j++
+			}
+
+			out = append(out, b[i:j]...)
+			styled = true
+			i = j
+			continue
+		}
+
+		r, size := utf8.DecodeRune(b[i:])
+		rw := readline.Runes{}.Width(r)
+		if w+rw > width {
+			truncated = true
+			break
+		}
+
+		out = append(out, b[i:i+size]...)
+		w += rw
+		i += size
+	}
+
+	if styled && truncated {
+		out = append(out, resetCode...)
+	}
+
+	for ; w < width; w++ {
+		out = append(out, ' ')
+	}
+
+	return out
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
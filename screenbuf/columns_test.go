@@ -0,0 +1,42 @@
+package screenbuf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteColumns(t *testing.T) {
+	var buf bytes.Buffer
+	s := New(&buf)
+
+	err := s.WriteColumns([]int{4, 6}, "|",
+		[][]byte{[]byte("parent"), []byte("\x1b[1mbold\x1b[0m")},
+		[][]byte{[]byte("\x1b[4mcurrent\x1b[0m"), []byte("界面"), []byte("last")},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error writing columns %v", err)
+	}
+
+	s.Flush()
+
+	lines := []string{
+		"pare|\x1b[4mcurren\x1b[0m",
+		"\x1b[1mbold\x1b[0m|界面  ",
+		"    |last  ",
+	}
+
+	var expect []byte
+	for _, line := range lines {
+		expect = append(expect, clearLine...)
+		expect = append(expect, line+"\n"...)
+	}
+
+	if !bytes.Equal(buf.Bytes(), expect) {
+		t.Errorf("Expected columns %q, got %q", expect, buf.Bytes())
+	}
+
+	err = s.WriteColumns([]int{4}, "|")
+	if err == nil {
+		t.Errorf("Expected error for missing columns")
+	}
+}
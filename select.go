@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"os"
+	"strings"
+	"sync"
 	"text/tabwriter"
 	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/chzyer/readline"
 	"github.com/lemotw/promptui/list"
@@ -16,6 +19,8 @@ import (
 // SelectedAdd is used internally inside SelectWithAdd when the add option is selected in select mode.
 // Since -1 is not a possible selected index, this ensure that add mode is always unique inside
 // SelectWithAdd's logic.
+//
+// Deprecated: SelectWithAdd adds the items in place and no longer returns it.
 const SelectedAdd = -1
 
 // Select represents a list of items used to enable selections, they can be used as search engines, menus
@@ -58,11 +63,109 @@ type Select struct {
 	// it is implemented.
 	Searcher list.Searcher
 
+	// Labeler is a function that returns the label used to jump to an item by typing the beginning of it.
+	//
+	// Jumping to items is only available when Searcher is not implemented. Typing j, k, h or l always moves
+	// through the list, so these keys are never part of the typed prefix. Labeler defaults to the output of the
+	// Inactive template without styling or surrounding spaces.
+	Labeler list.Labeler
+
+	// TypeAheadTimeout is the delay after which typed characters stop being appended to the label prefix
+	// used to jump to an item. Defaults to one second.
+	TypeAheadTimeout time.Duration
+
+	// HotkeyFunc is a function returning the key that immediately selects an item. Items implementing the
+	// Hotkeyer interface declare their own hotkey instead. When any item has a hotkey, the default Active and
+	// Inactive templates highlight it inside the item's label.
+	//
+	// Hotkeys take precedence over the j, k, h and l navigation keys and over jumping to an item by typing its
+	// label, except while a label is being typed. They are ignored in search mode. Run returns an error when
+	// two items share the same hotkey or when a hotkey is also the search key or a quick select number.
+	HotkeyFunc HotkeyFunc
+
+	// IsSelectable is a function that tells whether an item can be selected. Items that can't be selected, like
+	// entries without permission, separators or section headers, are rendered with the Disabled template and
+	// skipped when moving through the list. All items can be selected by default.
+	IsSelectable list.Selectable
+
+	// QuickSelect sets how the number keys 1 to 9 act on the visible items of the list. Only the items that
+	// can be selected are numbered. When enabled, the default Active and Inactive templates display the number
+	// of each visible item next to it. Defaults to QuickSelectNone.
+	QuickSelect QuickSelectMode
+
+	// Actions is a function returning the secondary actions of an item, like "open", "copy" or "delete".
+	// Items implementing the Actioner interface declare their own actions instead. Pressing the actions key
+	// on an item with actions opens a menu listing them, and the chosen action is returned by RunWithAction.
+	Actions ActionsFunc
+
+	// ActionTemplates can be used to customize the action menu, which is rendered like a select listing the
+	// actions. Its label receives the item whose actions are listed. If nil, the default templates are used.
+	ActionTemplates *SelectTemplates
+
+	// Sorts are the orders the items can be sorted by, keyed by their name. When Sorts is not nil, the sort key
+	// cycles through the original order, the alphabetical order of the labels and then the orders of Sorts by
+	// name. Each order is given as a function telling whether an item is displayed before another one. The
+	// cursor stays on the selected item when the order changes. The current order is shown inside the help,
+	// and the index returned by Run stays the one inside Items.
+	Sorts map[string]func(a, b interface{}) bool
+
+	// Preview is a function returning the preview of an item, displayed inside the preview pane in place of
+	// the Preview template, like the contents of a file. It runs in the background for the active item, one
+	// item at a time, and its result is cached for each item, the pane showing that the preview is loading in
+	// the meantime. Errors are displayed inside the pane.
+	Preview PreviewFunc
+
+	// PreviewPosition sets whether the preview pane is displayed below or beside the list. The pane is only
+	// displayed when the Preview function or template is set. Defaults to PreviewBottom.
+	PreviewPosition PreviewPosition
+
+	// PreviewSize is the height of the preview pane in lines when it is below the list, or its width in
+	// columns when it is beside the list. Longer previews scroll inside the pane. Defaults to 5 lines or 40
+	// columns.
+	PreviewSize int
+
 	// Size is the number of items that should appear on the select before scrolling is necessary. Defaults to 5.
 	Size int
 	// CursorPos is the initial position of the cursor.
 	CursorPos int
 
+	// hotkeys maps the declared hotkeys to the index of their item
+	hotkeys map[rune]int
+	// action is the action chosen from the action menu
+	action string
+	// sorting is the name of the order the list is sorted by
+	sorting string
+	// previews caches the results of the Preview function by item
+	previews map[string]*preview
+	// previewing tells whether the Preview function is running
+	previewing bool
+	// previewed is the item whose preview is displayed, and previewTop the first line displayed
+	previewed  string
+	previewTop int
+	// mu guards the list and the screen of a running select against its handles
+	mu sync.Mutex
+	// redraw renders the select while it runs
+	redraw func()
+	// onSubmit is called with the active item when enter is pressed and returns whether the item is
+	// selected. When it returns false the select keeps running. The term holds the searched term when in
+	// search mode.
+	onSubmit func(item interface{}, term string) bool
+	// onKey is called with each key before the select handles it and returns whether it was handled. The
+	// term holds the searched term when in search mode, searching telling whether the select is in search
+	// mode, even before a term is typed.
+	onKey func(key rune, term string, searching bool) bool
+	// renderRow renders an item of the list instead of the select templates and returns whether it did so.
+	renderRow func(item interface{}, active bool) ([]byte, bool)
+	// onSearch filters the list instead of its searcher. It receives an empty term when the search is
+	// canceled.
+	onSearch func(term string)
+	// filterKey is called with each key before readline handles it and returns whether it was handled, in
+	// which case readline ignores the key. The term holds the searched term when in search mode.
+	filterKey func(key rune, term string) bool
+	// header renders a row written between the label and the items, which stays in place while the list
+	// scrolls.
+	header func() []byte
+
 	// IsVimMode sets whether to use vim mode when using readline in the command prompt. Look at
 	// https://godoc.org/github.com/chzyer/readline#Config for more information on readline.
 	IsVimMode bool
@@ -75,6 +178,21 @@ type Select struct {
 	StartInSearchMode bool
 }
 
+// QuickSelectMode defines the behavior of the number keys inside a select. Each number key matches the visible
+// item at the same position, starting from the top of the current page.
+type QuickSelectMode int
+
+const (
+	// QuickSelectNone disables the number keys.
+	QuickSelectNone QuickSelectMode = iota
+
+	// QuickSelectJump moves the cursor to the item matching the number key.
+	QuickSelectJump
+
+	// QuickSelectSubmit selects the item matching the number key as if it was chosen with enter.
+	QuickSelectSubmit
+)
+
 // SelectKeys defines the available keys used by select mode to enable the user to move around the list
 // and trigger search mode. See the Key struct docs for more information on keys.
 type SelectKeys struct {
@@ -92,6 +210,18 @@ type SelectKeys struct {
 
 	// Search is the key used to trigger the search mode for the list. Default to the "/" key.
 	Search Key
+
+	// Actions is the key used to open and close the action menu of the active item. Defaults to the tab key.
+	Actions Key
+
+	// Sort is the key used to sort the list by the next order of Sorts. Defaults to the ctrl+o key.
+	Sort Key
+
+	// PreviewUp is the key used to scroll the preview pane up. Defaults to the ctrl+y key.
+	PreviewUp Key
+
+	// PreviewDown is the key used to scroll the preview pane down. Defaults to the ctrl+e key.
+	PreviewDown Key
 }
 
 // Key defines a keyboard code and a display representation for the help menu.
@@ -139,7 +269,9 @@ type SelectTemplates struct {
 	active   *template.Template
 	inactive *template.Template
 	selected *template.Template
+	disabled *template.Template
 	details  *template.Template
+	preview  *template.Template
 	help     *template.Template
 
 	// FuncMap is a map of helper functions that can be used inside of templates according to the text/template
@@ -147,6 +279,9 @@ type SelectTemplates struct {
 	//
 	// By default, FuncMap contains the color functions used to color the text in templates. If FuncMap
 	// is overridden, the colors functions must be added in the override from promptui.FuncMap to work.
+	//
+	// The quickKey helper is always available and displays the number key of the item being rendered
+	// when QuickSelect is enabled. The hotkey helper displays an item with its hotkey between brackets.
 	FuncMap template.FuncMap
 
 	// Label is a text/template for the main command line label. Defaults to printing the label as it with
@@ -163,6 +298,10 @@ type SelectTemplates struct {
 	// Selected is a text/template for when an item was successfully selected.
 	Selected string
 
+	// Disabled is a text/template for items that can't be selected, as told by the select's IsSelectable
+	// function. It is used instead of the Active and Inactive templates for those items.
+	Disabled string
+
 	// Details is a text/template for when an item current active to show
 	// additional information. It can have multiple lines.
 	//
@@ -172,6 +311,11 @@ type SelectTemplates struct {
 	// promptui will not trim spaces and tabs will be displayed if the template is indented.
 	Details string
 
+	// Preview is a text/template for the preview pane of the active item, displayed beside or below the list
+	// as set by the select's PreviewPosition. It can have multiple lines and scrolls inside the pane. The
+	// select's Preview function is used instead when it is set.
+	Preview string
+
 	// Help is a text/template for displaying instructions at the top. By default
 	// it shows keys for movement and search.
 	Help string
@@ -180,6 +324,9 @@ type SelectTemplates struct {
 // SearchPrompt is the prompt displayed in search mode.
 var SearchPrompt = "Search: "
 
+// DefaultTypeAheadTimeout is the default delay used by selects to reset the typed prefix when jumping to an item.
+var DefaultTypeAheadTimeout = time.Second
+
 // Run executes the select list. It displays the label and the list of items, asking the user to chose any
 // value within to list. Run will keep the prompt alive until it has been canceled from
 // the command prompt or it has received a valid value. It will return the value and an error if any
@@ -204,15 +351,34 @@ func (s *Select) RunCursorAt(cursorPos, scroll int) (int, interface{}, error) {
 		return 0, "", err
 	}
 	l.Searcher = s.Searcher
+	l.IsSelectable = s.IsSelectable
+	l.OnChange = s.setItems
 
 	s.list = l
+	s.sorting = ""
+	s.previewed, s.previewTop = "", 0
 
 	s.setKeys()
 
+	err = s.prepareHotkeys()
+	if err != nil {
+		return 0, "", err
+	}
+
 	err = s.prepareTemplates()
 	if err != nil {
 		return 0, "", err
 	}
+
+	if s.hasActions() {
+		menu := &Select{Templates: s.ActionTemplates}
+		err = menu.prepareTemplates()
+		if err != nil {
+			return 0, "", err
+		}
+		s.ActionTemplates = menu.Templates
+	}
+
 	return s.innerRun(cursorPos, scroll, ' ')
 }
 
@@ -250,10 +416,202 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 	s.list.SetCursor(cursorPos)
 	s.list.SetStart(scroll)
 
+	if s.list.Labeler == nil {
+		s.list.Labeler = s.labeler()
+	}
+
+	timeout := s.TypeAheadTimeout
+	if timeout == 0 {
+		timeout = DefaultTypeAheadTimeout
+	}
+
+	var typed []rune
+	var typedAt time.Time
+
+	typing := func() bool {
+		return len(typed) > 0 && time.Since(typedAt) < timeout
+	}
+
+	// typeAhead jumps to the item matching the typed prefix. A new prefix is only kept if an item matches it.
+	typeAhead := func(key rune) bool {
+		if !typing() {
+			typed = typed[:0]
+		}
+		typed = append(typed, key)
+		typedAt = time.Now()
+
+		if s.list.JumpTo(string(typed)) {
+			return true
+		}
+
+		if len(typed) == 1 {
+			typed = typed[:0]
+		}
+		return false
+	}
+
+	quickSelect := func(key rune) bool {
+		if s.QuickSelect == QuickSelectNone || searchMode || key < '1' || key > '9' {
+			return false
+		}
+
+		// only the selectable items are numbered
+		number := int(key-'1') + 1
+		items, _ := s.list.Items()
+		for i, item := range items {
+			if !s.selectable(item) {
+				continue
+			}
+
+			number--
+			if number == 0 {
+				s.list.SetCursor(s.list.Start() + i)
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// menu is the action menu of the active item while it is open
+	var menu *Select
+	s.action = ""
+
+	// draw renders the select. It is also called by the handles of the select when they change its items.
+	draw := func() {
+		if menu != nil {
+			if !s.HideHelp {
+				sb.Write(menu.renderHelp(false))
+			}
+			menu.writeList(sb, ' ')
+			sb.Flush()
+			return
+		}
+
+		if searchMode {
+			header := SearchPrompt + cur.Format()
+			sb.WriteString(header)
+		} else if !s.HideHelp {
+			help := s.renderHelp(canSearch)
+			sb.Write(help)
+		}
+
+		s.writeList(sb, top)
+		sb.Flush()
+	}
+
+	// rejected tells whether the last enter was refused by onSubmit. It is set before readline handles the
+	// key, so that it is known by the time the line ends.
+	rejected := false
+
+	// submitting has to happen before readline handles the key, as only enter ends the line
+	c.FuncFilterInputRune = func(key rune) (rune, bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// readline reads the end of the input as 0
+		if key == 0 {
+			return key, true
+		}
+
+		// the action menu handles its keys by itself, so that readline doesn't ring on tab
+		if menu != nil {
+			switch {
+			case key == KeyEnter:
+				items, idx := menu.list.Items()
+				if idx != list.NotFound {
+					s.action = items[idx].(string)
+					rejected = false
+				}
+				return key, true
+			case stopsReading(key):
+				return key, true
+			case key == s.Keys.Next.Code || key == 'j':
+				menu.list.Next()
+			case key == s.Keys.Prev.Code || key == 'k':
+				menu.list.Prev()
+			case key == s.actionsKey().Code || key == KeyBackward:
+				menu = nil
+			}
+
+			draw()
+			return key, false
+		}
+
+		if s.filterKey != nil && (key == KeyEnter || !stopsReading(key)) {
+			term := ""
+			if searchMode {
+				term = cur.Get()
+			}
+
+			if s.filterKey(key, term) {
+				if key == KeyEnter {
+					// enter still ends the line, as a rejected submit
+					rejected = true
+					return key, true
+				}
+
+				draw()
+				return key, false
+			}
+		}
+
+		if key == s.actionsKey().Code {
+			menu = s.actionMenu()
+			if menu != nil {
+				draw()
+				return key, false
+			}
+		}
+
+		if s.QuickSelect == QuickSelectSubmit && quickSelect(key) {
+			key = KeyEnter
+		} else if i, ok := s.hotkeyItem(key); ok && !searchMode && !typing() {
+			if s.list.SetIndex(i) {
+				key = KeyEnter
+			}
+		}
+
+		if key == KeyEnter {
+			rejected = false
+
+			items, idx := s.list.Items()
+			if s.onSubmit != nil && idx != list.NotFound {
+				term := ""
+				if searchMode {
+					term = cur.Get()
+				}
+				rejected = !s.onSubmit(items[idx], term)
+			}
+		}
+
+		return key, true
+	}
+
 	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		term := ""
+		if searchMode {
+			term = cur.Get()
+		}
+
+		handled := s.onKey != nil && s.onKey(key, term, searchMode)
+
 		switch {
-		case key == KeyEnter:
+		case key == KeyEnter && !rejected:
 			return nil, 0, true
+		case handled, key == KeyEnter:
+		case quickSelect(key):
+		case s.Sorts != nil && key == s.sortKey().Code:
+			s.nextSort()
+		case s.hasPreview() && key == s.previewUpKey().Code:
+			s.scrollPreview(-1)
+		case s.hasPreview() && key == s.previewDownKey().Code:
+			s.scrollPreview(1)
+		case !canSearch && typing() && unicode.IsPrint(key) && !strings.ContainsRune("jkhl", key):
+			typeAhead(key)
 		case key == s.Keys.Next.Code || (key == 'j' && !searchMode):
 			s.list.Next()
 		case key == s.Keys.Prev.Code || (key == 'k' && !searchMode):
@@ -266,7 +624,7 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 			if searchMode {
 				searchMode = false
 				cur.Replace("")
-				s.list.CancelSearch()
+				s.search("")
 			} else {
 				searchMode = true
 			}
@@ -276,80 +634,31 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 			}
 
 			cur.Backspace()
-			if len(cur.Get()) > 0 {
-				s.list.Search(cur.Get())
-			} else {
-				s.list.CancelSearch()
-			}
+			s.search(cur.Get())
 		case key == s.Keys.PageUp.Code || (key == 'h' && !searchMode):
 			s.list.PageUp()
 		case key == s.Keys.PageDown.Code || (key == 'l' && !searchMode):
 			s.list.PageDown()
+		case key == 0:
+			// readline starts each line with 0, which must not search again after a rejected submit
 		default:
 			if canSearch && searchMode {
 				cur.Update(string(line))
-				s.list.Search(cur.Get())
-			}
-		}
-
-		if searchMode {
-			header := SearchPrompt + cur.Format()
-			sb.WriteString(header)
-		} else if !s.HideHelp {
-			help := s.renderHelp(canSearch)
-			sb.Write(help)
-		}
-
-		label := render(s.Templates.label, s.Label)
-		sb.Write(label)
-
-		items, idx := s.list.Items()
-		last := len(items) - 1
-
-		for i, item := range items {
-			page := " "
-
-			switch i {
-			case 0:
-				if s.list.CanPageUp() {
-					page = "↑"
-				} else {
-					page = string(top)
-				}
-			case last:
-				if s.list.CanPageDown() {
-					page = "↓"
-				}
-			}
-
-			output := []byte(page + " ")
-
-			if i == idx {
-				output = append(output, render(s.Templates.active, item)...)
-			} else {
-				output = append(output, render(s.Templates.inactive, item)...)
-			}
-
-			sb.Write(output)
-		}
-
-		if idx == list.NotFound {
-			sb.WriteString("")
-			sb.WriteString("No results")
-		} else {
-			active := items[idx]
-
-			details := s.renderDetails(active)
-			for _, d := range details {
-				sb.Write(d)
+				s.search(cur.Get())
+			} else if !canSearch && unicode.IsPrint(key) {
+				typeAhead(key)
 			}
 		}
 
-		sb.Flush()
+		draw()
 
 		return nil, 0, true
 	})
 
+	s.mu.Lock()
+	s.redraw = draw
+	s.mu.Unlock()
+
 	for {
 		_, err = rl.Readline()
 
@@ -363,13 +672,21 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 			break
 		}
 
+		s.mu.Lock()
 		_, idx := s.list.Items()
-		if idx != list.NotFound {
+		done := idx != list.NotFound && s.list.CanSelect() && !rejected
+		s.mu.Unlock()
+
+		if done {
 			break
 		}
 
 	}
 
+	s.mu.Lock()
+	s.redraw = nil
+	s.mu.Unlock()
+
 	if err != nil {
 		if err.Error() == "Interrupt" {
 			err = ErrInterrupt
@@ -382,8 +699,10 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 		return 0, nil, err
 	}
 
+	s.mu.Lock()
 	items, idx := s.list.Items()
-	item := items[idx]
+	item, index := items[idx], s.list.Index()
+	s.mu.Unlock()
 
 	if s.HideSelected {
 		clearScreen(sb)
@@ -396,7 +715,7 @@ func (s *Select) innerRun(cursorPos, scroll int, top rune) (int, interface{}, er
 	rl.Write([]byte(showCursor))
 	rl.Close()
 
-	return s.list.Index(), item, err
+	return index, item, err
 }
 
 // ScrollPosition returns the current scroll position.
@@ -414,22 +733,32 @@ func (s *Select) prepareTemplates() error {
 		tpls.FuncMap = FuncMap
 	}
 
+	funcs := s.funcMap(tpls.FuncMap)
+
 	if tpls.Label == "" {
 		tpls.Label = fmt.Sprintf("%s {{.}}: ", IconInitial)
 	}
 
-	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Label)
+	tpl, err := template.New("").Funcs(funcs).Parse(tpls.Label)
 	if err != nil {
 		return err
 	}
 
 	tpls.label = tpl
 
+	number, item := "", "."
+	if s.QuickSelect != QuickSelectNone {
+		number = "{{ quickKey | bold }} "
+	}
+	if len(s.hotkeys) > 0 {
+		item = "hotkey ."
+	}
+
 	if tpls.Active == "" {
-		tpls.Active = fmt.Sprintf("%s {{ . | underline }}", IconSelect)
+		tpls.Active = fmt.Sprintf("%s %s{{ %s | underline }}", IconSelect, number, item)
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Active)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Active)
 	if err != nil {
 		return err
 	}
@@ -437,28 +766,45 @@ func (s *Select) prepareTemplates() error {
 	tpls.active = tpl
 
 	if tpls.Inactive == "" {
-		tpls.Inactive = "  {{.}}"
+		if number != "" {
+			number = "{{ quickKey | faint }} "
+		}
+		tpls.Inactive = fmt.Sprintf("  %s{{ %s }}", number, item)
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Inactive)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Inactive)
 	if err != nil {
 		return err
 	}
 
 	tpls.inactive = tpl
 
+	if tpls.Disabled == "" {
+		if number != "" {
+			number = "  "
+		}
+		tpls.Disabled = fmt.Sprintf("  %s{{ . | faint }}", number)
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Disabled)
+	if err != nil {
+		return err
+	}
+
+	tpls.disabled = tpl
+
 	if tpls.Selected == "" {
 		tpls.Selected = fmt.Sprintf(`{{ "%s" | green }} {{ . | faint }}`, IconGood)
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Selected)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Selected)
 	if err != nil {
 		return err
 	}
 	tpls.selected = tpl
 
 	if tpls.Details != "" {
-		tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Details)
+		tpl, err = template.New("").Funcs(funcs).Parse(tpls.Details)
 		if err != nil {
 			return err
 		}
@@ -466,13 +812,26 @@ func (s *Select) prepareTemplates() error {
 		tpls.details = tpl
 	}
 
+	if tpls.Preview != "" {
+		tpl, err = template.New("").Funcs(funcs).Parse(tpls.Preview)
+		if err != nil {
+			return err
+		}
+
+		tpls.preview = tpl
+	}
+
 	if tpls.Help == "" {
 		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} ` +
 			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} ` +
-			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}`)
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}` +
+			`{{ if .QuickSelect }} {{ "1-9" | faint }} {{ "picks an item" | faint }}{{ end }}` +
+			`{{ if .Actions }} {{ .ActionsKey | faint }} {{ "shows actions" | faint }}{{ end }}` +
+			`{{ if .Sort }} {{ .SortKey | faint }} {{ "sorts," | faint }} {{ "by" | faint }} {{ .Sort | faint }}{{ end }}` +
+			`{{ if .Preview }} {{ .PreviewUpKey | faint }} {{ .PreviewDownKey | faint }} {{ "scroll the preview" | faint }}{{ end }}`)
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Help)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Help)
 	if err != nil {
 		return err
 	}
@@ -485,24 +844,39 @@ func (s *Select) prepareTemplates() error {
 }
 
 // SelectWithAdd represents a list for selecting a single item inside a list of items with the possibility to
-// add new items to the list.
+// add, rename and delete items in place.
 type SelectWithAdd struct {
 	// Items are the items to display inside the list. Each item will be listed individually with the
-	// AddLabel as the first item of the list.
+	// AddLabel as the first item of the list. They are updated as items are added, renamed and deleted.
 	Items []string
 	// a function that defines how to render the cursor
 	Pointer Pointer
-	// Validate is an optional function that fill be used against the entered value in the prompt to validate it.
-	// If the value is valid, it is returned to the callee to be added in the list.
+	// Validate is an optional function that fill be used against the entered value to validate it. If the
+	// value is valid, the item is added or renamed. Otherwise the error is displayed next to the value.
 	Validate ValidateFunc
 
 	// Label is the text displayed on top of the list to direct input. The IconInitial value "?" will be
 	// appended automatically to the label so it does not need to be added.
 	Label string
 	// AddLabel is the label used for the first item of the list that enables adding a new item.
-	// Selecting this item in the list displays the add item prompt using promptui/prompt.
+	// Selecting this item in the list adds a new item below it. The item is not displayed when empty.
 	AddLabel string
 
+	// Templates can be used to customize the select output. If nil is passed, the default templates are used.
+	// See the SelectTemplates docs for more info.
+	Templates *SelectTemplates
+	// Keys is the set of keys used to control the interface. See the SelectWithAddKeys docs for more info.
+	Keys *SelectWithAddKeys
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+	// Searcher is a function that can be implemented to refine the base searching algorithm in selects. It
+	// receives the index of the item inside Items, which is kept up to date as the items change.
+	Searcher list.Searcher
+
+	// Size is the number of items that should appear on the select before scrolling is necessary. Defaults to 5.
+	Size int
+
 	// IsVimMode sets whether to use vim mode when using readline in the command prompt. Look at
 	// https://godoc.org/github.com/chzyer/readline#Config for more information on readline.
 	IsVimMode bool
@@ -510,55 +884,352 @@ type SelectWithAdd struct {
 	HideHelp bool
 }
 
+// SelectWithAddKeys defines the available keys used by a SelectWithAdd. It extends the SelectKeys with the
+// keys used to manage the items. While an item is edited, enter saves it.
+type SelectWithAddKeys struct {
+	SelectKeys
+
+	// Add is the key used to add an item below the active one. Defaults to KeyAdd.
+	Add Key
+
+	// Rename is the key used to rename the active item. Defaults to KeyRename.
+	Rename Key
+
+	// Delete is the key used to delete the active item. Defaults to KeyDelete.
+	Delete Key
+
+	// Cancel is the key used to cancel the edition of an item. Defaults to KeyCancel.
+	Cancel Key
+}
+
 // Run executes the select list. Its displays the label and the list of items, asking the user to chose any
-// value within to list or add his own. Run will keep the prompt alive until it has been canceled from
-// the command prompt or it has received a valid value.
+// value within to list. The items can be added, renamed and deleted in place while the list is displayed.
+// Run will keep the prompt alive until it has been canceled from the command prompt or it has received a
+// valid value.
 //
-// If the addLabel is selected in the list, this function will return a -1 index with the added label and no error.
-// Otherwise, it will return the index and the value of the selected item. In any case, if an error is triggered, it
-// will also return the error as its third return value.
-func (sa *SelectWithAdd) Run() (int, interface{}, error) {
-	if len(sa.Items) > 0 {
-		newItems := append([]string{sa.AddLabel}, sa.Items...)
-
-		list, err := list.New(newItems, 5)
-		if err != nil {
-			return 0, nil, err
+// It returns the index and the value of the selected item along with the final list of items, which are
+// also kept in Items. In any case, if an error is triggered, it will also return the error as its last
+// return value.
+func (sa *SelectWithAdd) Run() (int, string, []string, error) {
+	if sa.Size == 0 {
+		sa.Size = 5
+	}
+
+	sa.setKeys()
+
+	// offset is the index of the first item inside the list, which starts with the add label if any
+	offset := 0
+	top := ' '
+	if sa.AddLabel != "" {
+		offset = 1
+		top = '+'
+	}
+
+	items := append([]string{}, sa.Items...)
+	if offset > 0 {
+		items = append([]string{sa.AddLabel}, items...)
+	}
+
+	l, err := list.New(items, sa.Size)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	s := &Select{
+		Label:     sa.Label,
+		Items:     items,
+		Templates: sa.Templates,
+		Keys:      &sa.Keys.SelectKeys,
+		Stdin:     sa.Stdin,
+		Stdout:    sa.Stdout,
+		Pointer:   sa.Pointer,
+		Size:      sa.Size,
+		IsVimMode: sa.IsVimMode,
+		HideHelp:  sa.HideHelp,
+		list:      l,
+	}
+
+	// editing tells whether an item is being edited, the value being typed into input, and adding whether
+	// the item at was added for the edition below the item at before
+	editing, adding := false, false
+	at, before := list.NotFound, list.NotFound
+	input := NewCursor("", sa.Pointer, false)
+	var inputErr error
+
+	if sa.Searcher != nil {
+		// the add label and the edited item are kept while searching
+		s.Searcher = func(input string, index int) bool {
+			return index < offset || (editing && index == at) || sa.Searcher(input, index-offset)
 		}
+		l.Searcher = s.Searcher
+	}
 
-		s := Select{
-			Label:     sa.Label,
-			Items:     newItems,
-			IsVimMode: sa.IsVimMode,
-			HideHelp:  sa.HideHelp,
-			Size:      5,
-			list:      list,
-			Pointer:   sa.Pointer,
+	// Items are kept up to date with the list before it is searched again
+	l.OnChange = func(values []interface{}) {
+		s.setItems(values)
+
+		sa.Items = make([]string, len(values)-offset)
+		for i, value := range values[offset:] {
+			sa.Items[i] = value.(string)
 		}
-		s.setKeys()
+	}
 
-		err = s.prepareTemplates()
-		if err != nil {
-			return 0, nil, err
+	err = sa.prepareTemplates(s)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	invalid, err := template.New("").Funcs(FuncMap).Parse(`{{ ">>" | red }} {{ . | red }}`)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	edit := func(index int, value string, added bool) {
+		editing, adding, at = true, added, index
+		input.Replace(value)
+		inputErr = nil
+	}
+
+	// add inserts an empty item below the active one and edits it
+	add := func() {
+		before = l.Index()
+		index := before + 1
+		if index < offset {
+			index = offset
 		}
 
-		selected, value, err := s.innerRun(1, 0, '+')
-		if err != nil || selected != 0 {
-			return selected - 1, value, err
+		// the item is edited before it is inserted, so that the search keeps it
+		edit(index, "", true)
+		l.Insert(index, "")
+		if !l.SetIndex(index) {
+			editing = false
+			l.Remove(index)
 		}
+	}
 
-		// XXX run through terminal for windows
-		os.Stdout.Write([]byte(upLine(1) + "\r" + clearLine))
+	s.onSubmit = func(item interface{}, term string) bool {
+		if offset > 0 && l.Index() == 0 {
+			add()
+			return false
+		}
+		return true
 	}
 
-	p := Prompt{
-		Label:     sa.AddLabel,
-		Validate:  sa.Validate,
-		IsVimMode: sa.IsVimMode,
-		Pointer:   sa.Pointer,
+	s.filterKey = func(key rune, term string) bool {
+		if !editing {
+			index := l.Index()
+
+			switch {
+			case term != "" && unicode.IsPrint(key):
+				return false
+			case key == sa.Keys.Add.Code:
+				add()
+			case key == sa.Keys.Rename.Code && index >= offset:
+				edit(index, sa.Items[index-offset], false)
+			case key == sa.Keys.Delete.Code && index >= offset:
+				l.Remove(index)
+			default:
+				return false
+			}
+
+			return true
+		}
+
+		switch key {
+		case KeyEnter:
+			if sa.Validate != nil {
+				inputErr = sa.Validate(input.Get())
+				if inputErr != nil {
+					break
+				}
+			}
+
+			editing = false
+			l.Update(at, input.Get())
+		case sa.Keys.Cancel.Code:
+			editing = false
+			if adding {
+				l.Remove(at)
+				l.SetIndex(before)
+			}
+		case KeyBackspace, KeyCtrlH:
+			input.Backspace()
+		case KeyBackward:
+			input.Move(-1)
+		case KeyForward:
+			input.Move(1)
+		default:
+			if unicode.IsPrint(key) {
+				input.Update(string(key))
+			}
+		}
+
+		return true
+	}
+
+	s.renderRow = func(item interface{}, active bool) ([]byte, bool) {
+		if !editing || !active {
+			return nil, false
+		}
+
+		output := []byte(fmt.Sprintf("%s %s", IconSelect, input.Format()))
+		if inputErr != nil {
+			output = append(output, ' ')
+			output = append(output, render(invalid, inputErr)...)
+		}
+
+		return output, true
+	}
+
+	index, value, err := s.innerRun(offset, 0, top)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	return index - offset, value.(string), sa.Items, nil
+}
+
+func (sa *SelectWithAdd) setKeys() {
+	if sa.Keys != nil {
+		return
+	}
+
+	s := &Select{}
+	s.setKeys()
+
+	sa.Keys = &SelectWithAddKeys{
+		SelectKeys: *s.Keys,
+		Add:        Key{Code: KeyAdd, Display: KeyAddDisplay},
+		Rename:     Key{Code: KeyRename, Display: KeyRenameDisplay},
+		Delete:     Key{Code: KeyDelete, Display: KeyDeleteDisplay},
+		Cancel:     Key{Code: KeyCancel, Display: KeyCancelDisplay},
+	}
+}
+
+func (sa *SelectWithAdd) prepareTemplates(s *Select) error {
+	tpls := s.Templates
+	if tpls == nil {
+		tpls = &SelectTemplates{}
+	}
+
+	if tpls.Help == "" {
+		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} `+
+			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} `+
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }} `+
+			`{{ "%s" | faint }} {{ "adds," | faint }} {{ "%s" | faint }} {{ "renames," | faint }} `+
+			`{{ "%s" | faint }} {{ "deletes" | faint }}`,
+			sa.Keys.Add.Display, sa.Keys.Rename.Display, sa.Keys.Delete.Display)
+	}
+
+	s.Templates = tpls
+
+	return s.prepareTemplates()
+}
+
+// search filters the list with the given term or cancels the search when the term is empty.
+func (s *Select) search(term string) {
+	switch {
+	case s.onSearch != nil:
+		s.onSearch(term)
+	case term == "":
+		s.list.CancelSearch()
+	default:
+		s.list.Search(term)
+	}
+}
+
+// stopsReading tells whether readline stops reading the input after the given key until the next line
+// starts, in which case the key can't be ignored.
+func stopsReading(key rune) bool {
+	switch key {
+	case readline.CharInterrupt, readline.CharEnter, readline.CharCtrlJ, readline.CharDelete:
+		return true
+	}
+	return false
+}
+
+// writeList writes the label and the visible items of the select, followed by the details of the active item.
+func (s *Select) writeList(sb *screenbuf.ScreenBuf, top rune) {
+	label := render(s.Templates.label, s.Label)
+	sb.Write(label)
+
+	if s.header != nil {
+		sb.Write(s.header())
+	}
+
+	items, idx := s.list.Items()
+	last := len(items) - 1
+	number := 0
+
+	var rows [][]byte
+
+	for i, item := range items {
+		page := " "
+
+		switch i {
+		case 0:
+			if s.list.CanPageUp() {
+				page = "↑"
+			} else {
+				page = string(top)
+			}
+		case last:
+			if s.list.CanPageDown() {
+				page = "↓"
+			}
+		}
+
+		output := []byte(page + " ")
+
+		if s.selectable(item) {
+			number++
+			output = append(output, s.renderItem(item, i == idx, number)...)
+		} else {
+			output = append(output, s.renderItem(item, i == idx, 0)...)
+		}
+
+		rows = append(rows, output)
+	}
+
+	var pane [][]byte
+	if s.hasPreview() {
+		var active interface{}
+		if idx != list.NotFound {
+			active = items[idx]
+		}
+
+		height := s.previewSize()
+		if s.PreviewPosition == PreviewSide {
+			height = s.Size
+		}
+
+		pane = s.previewPane(active, height)
+	}
+
+	if s.PreviewPosition == PreviewSide && pane != nil {
+		rows = besides(rows, pane)
+		pane = nil
+	}
+
+	for _, row := range rows {
+		sb.Write(row)
+	}
+
+	if idx == list.NotFound {
+		sb.WriteString("")
+		sb.WriteString("No results")
+	} else {
+		active := items[idx]
+
+		details := s.renderDetails(active)
+		for _, d := range details {
+			sb.Write(d)
+		}
+	}
+
+	for _, line := range pane {
+		sb.Write(line)
 	}
-	value, err := p.Run()
-	return SelectedAdd, value, err
 }
 
 func (s *Select) setKeys() {
@@ -571,6 +1242,78 @@ func (s *Select) setKeys() {
 		PageUp:   Key{Code: KeyBackward, Display: KeyBackwardDisplay},
 		PageDown: Key{Code: KeyForward, Display: KeyForwardDisplay},
 		Search:   Key{Code: '/', Display: "/"},
+		Actions:  Key{Code: KeyTab, Display: KeyTabDisplay},
+		Sort:     Key{Code: KeySort, Display: KeySortDisplay},
+
+		PreviewUp:   Key{Code: KeyPreviewUp, Display: KeyPreviewUpDisplay},
+		PreviewDown: Key{Code: KeyPreviewDown, Display: KeyPreviewDownDisplay},
+	}
+}
+
+// funcMap returns the given template helpers extended with the helpers bound to the state of the select.
+func (s *Select) funcMap(funcs template.FuncMap) template.FuncMap {
+	m := template.FuncMap{}
+	for name, fn := range funcs {
+		m[name] = fn
+	}
+
+	// quickKey is bound to the number key of each visible item when rendering the list
+	m["quickKey"] = quickKey(0)
+
+	m["hotkey"] = s.hotkeyLabel
+
+	return m
+}
+
+// renderItem renders an item of the list. The number is the 1-based position used by the quick select keys
+// to reach the item, or 0 if it can't be reached.
+func (s *Select) renderItem(item interface{}, active bool, number int) []byte {
+	if s.renderRow != nil {
+		if output, ok := s.renderRow(item, active); ok {
+			return output
+		}
+	}
+
+	tpl := s.Templates.inactive
+	switch {
+	case !s.selectable(item):
+		tpl = s.Templates.disabled
+	case active:
+		tpl = s.Templates.active
+	}
+
+	if s.QuickSelect == QuickSelectNone {
+		return render(tpl, item)
+	}
+
+	return renderWith(tpl, item, template.FuncMap{"quickKey": quickKey(number)})
+}
+
+// quickKey returns a template helper displaying the quick select key of the given 1-based number.
+func quickKey(number int) func() string {
+	return func() string {
+		if number < 1 || number > 9 {
+			return " "
+		}
+		return fmt.Sprint(number)
+	}
+}
+
+func (s *Select) selectable(item interface{}) bool {
+	return s.IsSelectable == nil || s.IsSelectable(item)
+}
+
+func (s *Select) labeler() list.Labeler {
+	if s.Labeler != nil {
+		return s.Labeler
+	}
+
+	// the label is rendered without the quick select numbers and the hotkey brackets
+	funcs := template.FuncMap{"quickKey": func() string { return "" }, "hotkey": plainLabel}
+
+	return func(item interface{}) string {
+		label := stripCodes(string(renderWith(s.Templates.inactive, item, funcs)))
+		return strings.TrimSpace(label)
 	}
 }
 
@@ -597,19 +1340,36 @@ func (s *Select) renderDetails(item interface{}) [][]byte {
 
 func (s *Select) renderHelp(b bool) []byte {
 	keys := struct {
-		NextKey     string
-		PrevKey     string
-		PageDownKey string
-		PageUpKey   string
-		SearchKey   string
-		Search      bool
+		NextKey        string
+		PrevKey        string
+		PageDownKey    string
+		PageUpKey      string
+		SearchKey      string
+		ActionsKey     string
+		SortKey        string
+		Sort           string
+		PreviewUpKey   string
+		PreviewDownKey string
+		Search         bool
+		QuickSelect    bool
+		Actions        bool
+		Preview        bool
 	}{
 		NextKey:     s.Keys.Next.Display,
 		PrevKey:     s.Keys.Prev.Display,
 		PageDownKey: s.Keys.PageDown.Display,
 		PageUpKey:   s.Keys.PageUp.Display,
 		SearchKey:   s.Keys.Search.Display,
-		Search:      b,
+		ActionsKey:  s.actionsKey().Display,
+		SortKey:     s.sortKey().Display,
+		Sort:        s.sortName(),
+
+		PreviewUpKey:   s.previewUpKey().Display,
+		PreviewDownKey: s.previewDownKey().Display,
+		Search:         b,
+		QuickSelect:    s.QuickSelect != QuickSelectNone,
+		Actions:        s.hasActions(),
+		Preview:        s.hasPreview(),
 	}
 
 	return render(s.Templates.help, keys)
@@ -624,6 +1384,16 @@ func render(tpl *template.Template, data interface{}) []byte {
 	return buf.Bytes()
 }
 
+// renderWith renders the template with some of its helpers replaced by the given ones. The template itself
+// is left untouched.
+func renderWith(tpl *template.Template, data interface{}, funcs template.FuncMap) []byte {
+	t, err := tpl.Clone()
+	if err != nil {
+		return render(tpl, data)
+	}
+	return render(t.Funcs(funcs), data)
+}
+
 func clearScreen(sb *screenbuf.ScreenBuf) {
 	sb.Reset()
 	sb.Clear()
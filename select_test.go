@@ -2,7 +2,12 @@ package promptui
 
 import (
 	"bytes"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lemotw/promptui/screenbuf"
 )
@@ -42,6 +47,12 @@ func TestSelectTemplateRender(t *testing.T) {
 		if result != exp {
 			t.Errorf("Expected selected item to eq %q, got %q", exp, result)
 		}
+
+		result = string(render(s.Templates.disabled, values[0]))
+		exp = "  \x1b[2mZero\x1b[0m"
+		if result != exp {
+			t.Errorf("Expected disabled item to eq %q, got %q", exp, result)
+		}
 	})
 
 	t.Run("when using custom style", func(t *testing.T) {
@@ -112,6 +123,42 @@ Description: {{.Description}}`,
 		}
 	})
 
+	t.Run("when using quick select", func(t *testing.T) {
+		values := []string{"Zero", "One"}
+		s := Select{
+			Label:       "Select Number",
+			Items:       values,
+			QuickSelect: QuickSelectJump,
+		}
+		err := s.prepareTemplates()
+		if err != nil {
+			t.Fatalf("Unexpected error preparing templates %v", err)
+		}
+
+		result := string(s.renderItem(values[0], true, 1))
+		exp := "\x1b[1m▸\x1b[0m \x1b[1m1\x1b[0m \x1b[4mZero\x1b[0m"
+		if result != exp {
+			t.Errorf("Expected active item to eq %q, got %q", exp, result)
+		}
+
+		result = string(s.renderItem(values[1], false, 2))
+		exp = "  \x1b[2m2\x1b[0m One"
+		if result != exp {
+			t.Errorf("Expected inactive item to eq %q, got %q", exp, result)
+		}
+
+		result = string(s.renderItem(values[1], false, 10))
+		exp = "  \x1b[2m \x1b[0m One"
+		if result != exp {
+			t.Errorf("Expected inactive item to eq %q, got %q", exp, result)
+		}
+
+		result = string(render(s.Templates.inactive, values[1]))
+		if result != exp {
+			t.Errorf("Expected item rendered outside the list to eq %q, got %q", exp, result)
+		}
+	})
+
 	t.Run("when a template is invalid", func(t *testing.T) {
 		templates := &SelectTemplates{
 			Label: "{{ . ",
@@ -166,3 +213,259 @@ func TestClearScreen(t *testing.T) {
 		t.Errorf("expected %q, got %q", except, got)
 	}
 }
+
+func TestSelectTypeAhead(t *testing.T) {
+	fruits := []string{"apple", "banana", "avocado", "kiwi"}
+
+	tcs := []struct {
+		name    string
+		input   string
+		timeout time.Duration
+		index   int
+	}{
+		{name: "when typing a prefix", input: "ba\r", index: 1},
+		{name: "when typing a prefix after the timeout", input: "ba\r", timeout: time.Nanosecond, index: 2},
+		{name: "when typing a navigation key matching a label", input: "jjk\r", index: 1},
+		{name: "when typing a navigation key matching no label", input: "j\r", index: 1},
+		{name: "when typing a navigation key after a prefix", input: "bj\r", index: 2},
+		{name: "when typing a prefix matching no label", input: "x\r", index: 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Select{
+				Label:            "Fruit",
+				Items:            fruits,
+				TypeAheadTimeout: tc.timeout,
+				Stdin:            ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:           &closeBuffer{},
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if index != tc.index {
+				t.Errorf("Expected index %d, got %d", tc.index, index)
+			}
+		})
+	}
+}
+
+func TestSelectDisabledItems(t *testing.T) {
+	items := []menuEntry{{Name: "-- file"}, {Name: "edit", Key: 'e'}, {Name: "delete", Key: 'd'}, {Name: "quit", Key: 'q'}}
+	isSelectable := func(item interface{}) bool {
+		entry := item.(menuEntry)
+		return entry.Name != "delete" && !strings.HasPrefix(entry.Name, "-")
+	}
+
+	tcs := []struct {
+		name        string
+		input       string
+		quickSelect QuickSelectMode
+		index       int
+	}{
+		{name: "when pressing the hotkey of a disabled item", input: "d\r", index: 1},
+		{name: "when pressing enter on a disabled item", input: "kk\r", index: 1},
+		{name: "when pressing the number of an item after a disabled item", input: "1\r", quickSelect: QuickSelectJump, index: 1},
+		{name: "when pressing the number of an item after disabled items", input: "2\r", quickSelect: QuickSelectJump, index: 3},
+		{name: "when pressing a number without item", input: "3\r", quickSelect: QuickSelectJump, index: 1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Select{
+				Label:        "Action",
+				Items:        items,
+				IsSelectable: isSelectable,
+				QuickSelect:  tc.quickSelect,
+				Stdin:        ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:       &closeBuffer{},
+			}
+
+			index, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if index != tc.index {
+				t.Errorf("Expected index %d, got %d", tc.index, index)
+			}
+		})
+	}
+
+	t.Run("when rendering quick select numbers", func(t *testing.T) {
+		s := Select{
+			Label:        "Action",
+			Items:        items,
+			IsSelectable: isSelectable,
+			QuickSelect:  QuickSelectJump,
+			Stdin:        ioutil.NopCloser(strings.NewReader("\r")),
+			Stdout:       &closeBuffer{},
+		}
+
+		_, _, err := s.Run()
+		if err != nil {
+			t.Fatalf("Unexpected error running select %v", err)
+		}
+
+		output := stripCodes(s.Stdout.(*closeBuffer).String())
+		for _, exp := range []string{"1 [e]dit", "2 [q]uit", "      delete"} {
+			if !strings.Contains(output, exp) {
+				t.Errorf("Expected output to contain %q, got %q", exp, output)
+			}
+		}
+	})
+}
+
+func TestSelectWithAddRun(t *testing.T) {
+	validate := func(input string) error {
+		if input == "" {
+			return errors.New("empty name")
+		}
+		return nil
+	}
+
+	tcs := []struct {
+		name   string
+		input  string
+		index  int
+		value  string
+		items  []string
+		output string
+	}{
+		{name: "when selecting an item", input: "\r", index: 0, value: "a", items: []string{"a", "b", "c"}},
+		{name: "when adding an item", input: "\x01new\r\r", index: 1, value: "new", items: []string{"a", "new", "b", "c"}},
+		{name: "when selecting the add label", input: "k\rz\r\r", index: 0, value: "z", items: []string{"z", "a", "b", "c"}},
+		{name: "when renaming an item", input: "j\x05\x08X\r\r", index: 1, value: "X", items: []string{"a", "X", "c"}},
+		{name: "when deleting an item", input: "\x0b\r", index: 0, value: "b", items: []string{"b", "c"}},
+		{name: "when canceling an edition", input: "\x01foo\x07\x05\x07\r", index: 0, value: "a", items: []string{"a", "b", "c"}},
+		{
+			name:   "when validating an item",
+			input:  "\x01\rok\r\r",
+			index:  1,
+			value:  "ok",
+			items:  []string{"a", "ok", "b", "c"},
+			output: ">> empty name",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout := &closeBuffer{}
+			s := SelectWithAdd{
+				Label:    "Letter",
+				Items:    []string{"a", "b", "c"},
+				AddLabel: "Add",
+				Validate: validate,
+				Stdin:    ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:   stdout,
+			}
+
+			index, value, items, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if index != tc.index || value != tc.value {
+				t.Errorf("Expected %s at %d, got %s at %d", tc.value, tc.index, value, index)
+			}
+
+			if !reflect.DeepEqual(items, tc.items) || !reflect.DeepEqual(s.Items, tc.items) {
+				t.Errorf("Expected items %v, got %v", tc.items, items)
+			}
+
+			if !strings.Contains(stripCodes(stdout.String()), tc.output) {
+				t.Errorf("Expected output to contain %q, got %q", tc.output, stripCodes(stdout.String()))
+			}
+		})
+	}
+
+	t.Run("when searching without add label", func(t *testing.T) {
+		s := SelectWithAdd{
+			Label: "Letter",
+			Items: []string{"apple", "banana", "cherry"},
+			Size:  2,
+			Stdin: ioutil.NopCloser(strings.NewReader("\x01berry\r/b\x0e\r")),
+		}
+		s.Stdout = &closeBuffer{}
+		s.Searcher = func(input string, index int) bool {
+			return strings.Contains(s.Items[index], input)
+		}
+
+		index, value, items, err := s.Run()
+		if err != nil {
+			t.Fatalf("Unexpected error running select %v", err)
+		}
+
+		if index != 2 || value != "banana" || len(items) != 4 {
+			t.Errorf("Expected banana at 2 among 4 items, got %s at %d among %v", value, index, items)
+		}
+	})
+
+	searching := []struct {
+		name  string
+		input string
+		index int
+		value string
+		items []string
+	}{
+		{
+			name:  "when adding an item while searching",
+			input: "/b\rberry\r\r",
+			index: 0,
+			value: "berry",
+			items: []string{"berry", "apple", "banana", "cherry"},
+		},
+		{
+			name:  "when adding an item below a match",
+			input: "/b\x0e\x01blueberry\r\r",
+			index: 2,
+			value: "blueberry",
+			items: []string{"apple", "banana", "blueberry", "cherry"},
+		},
+		{
+			name:  "when renaming an item while searching",
+			input: "/ch\x0e\x05\x08ies\r\r",
+			index: 2,
+			value: "cherries",
+			items: []string{"apple", "banana", "cherries"},
+		},
+		{
+			name:  "when canceling an addition while searching",
+			input: "/b\x0e\x01foo\x07\r",
+			index: 1,
+			value: "banana",
+			items: []string{"apple", "banana", "cherry"},
+		},
+	}
+
+	for _, tc := range searching {
+		t.Run(tc.name, func(t *testing.T) {
+			s := SelectWithAdd{
+				Label:    "Fruit",
+				Items:    []string{"apple", "banana", "cherry"},
+				AddLabel: "Add",
+				Stdin:    ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:   &closeBuffer{},
+			}
+			s.Searcher = func(input string, index int) bool {
+				return strings.Contains(s.Items[index], input)
+			}
+
+			index, value, items, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if index != tc.index || value != tc.value {
+				t.Errorf("Expected %s at %d, got %s at %d", tc.value, tc.index, value, index)
+			}
+
+			if !reflect.DeepEqual(items, tc.items) {
+				t.Errorf("Expected items %v, got %v", tc.items, items)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package promptui
+
+import (
+	"sort"
+	"strings"
+)
+
+// These are the names of the orders every sortable select can be sorted by, before the ones of its Sorts.
+const (
+	// SortOriginal is the order of the items as they were given to the select.
+	SortOriginal = "original"
+
+	// SortAlphabetical is the alphabetical order of the labels of the items, ignoring case. The labels are
+	// the ones used to jump to the items, given by the Labeler of the select.
+	SortAlphabetical = "alphabetical"
+)
+
+// sortNames returns the names of the orders the select cycles through, nil being returned when the select
+// can't be sorted. The orders of Sorts follow the original and alphabetical orders, sorted by name.
+func (s *Select) sortNames() []string {
+	if s.Sorts == nil {
+		return nil
+	}
+
+	var names []string
+	for name := range s.Sorts {
+		if name != SortOriginal && name != SortAlphabetical {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{SortOriginal, SortAlphabetical}, names...)
+}
+
+// sortKey returns the key cycling through the orders, ctrl+o being used when the keys don't set any.
+func (s *Select) sortKey() Key {
+	if s.Keys.Sort.Code == 0 {
+		return Key{Code: KeySort, Display: KeySortDisplay}
+	}
+	return s.Keys.Sort
+}
+
+// nextSort sorts the list by the order following the current one.
+func (s *Select) nextSort() {
+	names := s.sortNames()
+	if len(names) == 0 {
+		return
+	}
+
+	next := 1
+	for i, name := range names {
+		if name == s.sorting {
+			next = i + 1
+		}
+	}
+
+	s.sortBy(names[next%len(names)])
+}
+
+// sortBy sorts the list by the order of the given name. Sorts take precedence over the default orders.
+func (s *Select) sortBy(name string) {
+	s.sorting = name
+
+	if less, ok := s.Sorts[name]; ok {
+		s.list.Sort(less)
+		return
+	}
+
+	switch name {
+	case SortAlphabetical:
+		label := s.labeler()
+		s.list.Sort(func(a, b interface{}) bool {
+			return strings.ToLower(label(a)) < strings.ToLower(label(b))
+		})
+	default:
+		s.list.Sort(nil)
+	}
+}
+
+// sortName returns the name of the current order, or an empty name when the select can't be sorted.
+func (s *Select) sortName() string {
+	switch {
+	case s.Sorts == nil:
+		return ""
+	case s.sorting == "":
+		return SortOriginal
+	}
+	return s.sorting
+}
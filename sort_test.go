@@ -0,0 +1,82 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestSelectSorts(t *testing.T) {
+	items := []string{"Cherry", "apple", "banana", "fig"}
+
+	sorts := map[string]func(a, b interface{}) bool{
+		"length": func(a, b interface{}) bool {
+			return len(a.(string)) < len(b.(string))
+		},
+	}
+
+	tcs := []struct {
+		name  string
+		sorts map[string]func(a, b interface{}) bool
+		input string
+		index int
+		sort  string
+	}{
+		{name: "when keeping the original order", sorts: sorts, input: "\r", index: 0, sort: "original"},
+		{name: "when sorting alphabetically", sorts: sorts, input: "\x0fk\r", index: 2, sort: "alphabetical"},
+		{name: "when sorting by a custom order", sorts: sorts, input: "\x0f\x0fk\r", index: 1, sort: "length"},
+		{name: "when cycling back to the original order", sorts: sorts, input: "\x0f\x0f\x0fj\r", index: 1, sort: "original"},
+		{name: "when keeping the selected item", sorts: sorts, input: "\x0f\x0f\r", index: 0, sort: "length"},
+		{name: "when only the default orders are available", sorts: map[string]func(a, b interface{}) bool{},
+			input: "\x0f\x0f\r", index: 0, sort: "original"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &closeBuffer{}
+			s := Select{
+				Label:  "Fruit",
+				Items:  items,
+				Sorts:  tc.sorts,
+				Stdin:  ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout: out,
+			}
+
+			idx, result, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running select %v", err)
+			}
+
+			if idx != tc.index || result != items[tc.index] {
+				t.Errorf("Expected %s at %d, got %s at %d", items[tc.index], tc.index, result, idx)
+			}
+
+			if s.sortName() != tc.sort {
+				t.Errorf("Expected the select to be sorted by %s, got %s", tc.sort, s.sortName())
+			}
+
+			if !strings.Contains(stripCodes(out.String()), "by "+tc.sort) {
+				t.Errorf("Expected the help to show the %s order, got %q", tc.sort, out.String())
+			}
+		})
+	}
+
+	t.Run("when the select has no sorts", func(t *testing.T) {
+		out := &closeBuffer{}
+		s := Select{
+			Label:  "Fruit",
+			Items:  items,
+			Stdin:  ioutil.NopCloser(strings.NewReader("\x0f\r")),
+			Stdout: out,
+		}
+
+		idx, _, err := s.Run()
+		if err != nil {
+			t.Fatalf("Unexpected error running select %v", err)
+		}
+
+		if idx != 0 || strings.Contains(stripCodes(out.String()), "sorts") {
+			t.Errorf("Expected the select not to be sorted, got %d and %q", idx, out.String())
+		}
+	})
+}
@@ -0,0 +1,509 @@
+package promptui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/chzyer/readline"
+	"github.com/lemotw/promptui/list"
+)
+
+// Alignment defines how the values of a column are aligned inside its width.
+type Alignment int
+
+const (
+	// AlignLeft aligns the values on the left of the column.
+	AlignLeft Alignment = iota
+
+	// AlignRight aligns the values on the right of the column, which suits numbers.
+	AlignRight
+
+	// AlignCenter centers the values inside the column.
+	AlignCenter
+)
+
+// Column declares a column of a TableSelect.
+type Column struct {
+	// Header is the title of the column displayed inside the header row. It is also the name used to search
+	// the column alone.
+	Header string
+
+	// Field is the name of the struct field or of the map key displayed inside the column. The item itself is
+	// displayed when neither Field nor Template is set.
+	Field string
+
+	// Template is a text/template rendering the value of the column from the item. It is used instead of
+	// Field and has access to the FuncMap of the templates of the table.
+	Template string
+
+	// Width is the width of the column. Longer values are cut, losing their styling. Defaults to the width of
+	// the widest value of the column across all items, header included.
+	Width int
+
+	// Align sets how the values are aligned inside the column. Defaults to AlignLeft.
+	Align Alignment
+
+	// SortKey is the key sorting the table by the column. Pressing it cycles through the ascending order,
+	// the descending order and the original order. Values are compared as numbers when both are numbers. The
+	// column can't be sorted when its code is zero, and the sort keys are ignored in search mode.
+	SortKey Key
+
+	tpl *template.Template
+}
+
+// TableRow is the value given to the templates of a TableSelect for each row of the table, and to its header
+// template for the header row.
+type TableRow struct {
+	// Item is the item of the row, or nil for the header row.
+	Item interface{}
+
+	// Cells are the values of the columns, aligned inside the width of their column.
+	Cells []string
+
+	// values are the values of the columns without padding or styling, used to search and sort
+	values []string
+}
+
+// String returns the cells of the row separated by two spaces.
+func (r *TableRow) String() string {
+	return strings.Join(r.Cells, "  ")
+}
+
+// TableSearcher is the function signature used to search the items of a TableSelect. It receives the searched
+// term and the value of a cell, as displayed without styling, and should return whether the cell fits the term.
+type TableSearcher func(input, value string) bool
+
+// TableSelect is a select list displaying its items as the rows of a table. The columns are declared with a
+// header and the field or template rendering their values, and are aligned across all items. The header row
+// stays on top of the list while it scrolls.
+type TableSelect struct {
+	// Label is the text displayed on top of the list to direct input. The IconInitial value "?" will be
+	// appended automatically to the label so it does not need to be added.
+	Label interface{}
+
+	// Items are the items to display as the rows of the table. It expects a slice of any kind of values, like
+	// Select.Items, usually of structs or maps.
+	Items interface{}
+
+	// Columns are the columns of the table. See the Column docs for more info.
+	Columns []Column
+
+	// Templates can be used to customize the table select output. If nil is passed, the default templates
+	// are used. See the TableSelectTemplates docs for more info.
+	Templates *TableSelectTemplates
+	// Keys is the set of keys used to control the interface. See the SelectKeys docs for more info.
+	Keys *SelectKeys
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+	// A function that determines how to render the cursor
+	Pointer Pointer
+	// Searcher is a function that can be implemented to search the cells of the table. A row matches when any
+	// of its cells fits the searched term. A term starting with the header of a column and a colon, like
+	// "name:bob", only searches that column. It is unimplemented by default and search will not work unless
+	// it is implemented.
+	Searcher TableSearcher
+
+	// Size is the number of rows, header excluded, that should appear before scrolling is necessary.
+	// Defaults to 5.
+	Size int
+
+	// IsVimMode sets whether to use vim mode when using readline in the command prompt.
+	IsVimMode bool
+	// HideHelp sets whether to hide help information.
+	HideHelp bool
+	// HideSelected sets whether to hide the text displayed after an item is successfully selected.
+	HideSelected bool
+	// StartInSearchMode sets whether or not the select should start in search mode.
+	StartInSearchMode bool
+}
+
+// TableSelectTemplates allow a table select to be customized. The SelectTemplates receive a TableRow for each
+// row of the table while the header template receives a TableRow of the headers.
+type TableSelectTemplates struct {
+	SelectTemplates
+
+	header *template.Template
+
+	// Header is a text/template for the header row. Defaults to the bold headers, aligned with the rows.
+	Header string
+}
+
+// Run executes the table select. It displays the label, the header row and the rows of the table, asking the
+// user to chose any item. It returns the index of the item inside Items, whatever the order of the table, the
+// item and an error if any occurred during the select's execution.
+func (t *TableSelect) Run() (int, interface{}, error) {
+	items, err := itemsOf(t.Items)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(t.Columns) == 0 {
+		return 0, nil, fmt.Errorf("table select has no columns")
+	}
+
+	if t.Size == 0 {
+		t.Size = 5
+	}
+
+	if t.Keys == nil {
+		s := &Select{}
+		s.setKeys()
+		t.Keys = s.Keys
+	}
+
+	err = t.prepareTemplates()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rows := make([]*TableRow, len(items))
+	for i, item := range items {
+		rows[i], err = t.row(item)
+		if err != nil {
+			return 0, nil, fmt.Errorf("item %d: %v", i, err)
+		}
+	}
+
+	l, err := list.New(rows, t.Size)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s := &Select{
+		Label:             t.Label,
+		Templates:         &t.Templates.SelectTemplates,
+		Keys:              t.Keys,
+		Stdin:             t.Stdin,
+		Stdout:            t.Stdout,
+		Pointer:           t.Pointer,
+		Size:              t.Size,
+		IsVimMode:         t.IsVimMode,
+		HideHelp:          t.HideHelp,
+		HideSelected:      t.HideSelected,
+		StartInSearchMode: t.StartInSearchMode,
+		list:              l,
+	}
+
+	if t.Searcher != nil {
+		s.Searcher = func(input string, i int) bool {
+			column, term := t.searchedColumn(input)
+
+			for j, value := range rows[i].values {
+				if (column == list.NotFound || column == j) && t.Searcher(term, value) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	l.Searcher = s.Searcher
+	l.Labeler = s.labeler()
+
+	headers := &TableRow{}
+	for _, c := range t.Columns {
+		headers.values = append(headers.values, c.Header)
+	}
+
+	// sorted is the column the table is sorted by, desc telling whether the order is descending
+	sorted, desc := list.NotFound, false
+
+	widths := t.layout(append(rows, headers))
+
+	s.header = func() []byte {
+		row := &TableRow{values: headers.values}
+		if sorted != list.NotFound {
+			row.values = append([]string{}, headers.values...)
+			if desc {
+				row.values[sorted] += " ↓"
+			} else {
+				row.values[sorted] += " ↑"
+			}
+		}
+
+		for i, c := range t.Columns {
+			row.Cells = append(row.Cells, align(c, row.values[i], widths[i]))
+		}
+
+		// the header lines up with the rows, which follow the page markers
+		return append([]byte("  "), render(t.Templates.header, row)...)
+	}
+
+	s.onKey = func(key rune, term string, searching bool) bool {
+		// the keys are typed into the search in search mode
+		if searching {
+			return false
+		}
+
+		for i, c := range t.Columns {
+			if c.SortKey.Code == 0 || key != c.SortKey.Code {
+				continue
+			}
+
+			switch {
+			case sorted != i:
+				sorted, desc = i, false
+			case !desc:
+				desc = true
+			default:
+				sorted = list.NotFound
+			}
+
+			if sorted == list.NotFound {
+				l.Sort(nil)
+				return true
+			}
+
+			column, reverse := sorted, desc
+			l.Sort(func(a, b interface{}) bool {
+				x, y := a.(*TableRow).values[column], b.(*TableRow).values[column]
+				if reverse {
+					return lessValue(y, x)
+				}
+				return lessValue(x, y)
+			})
+			return true
+		}
+
+		return false
+	}
+
+	_, _, err = s.innerRun(0, 0, ' ')
+	if err != nil {
+		return 0, nil, err
+	}
+
+	idx := l.Index()
+	return idx, items[idx], nil
+}
+
+// row renders the values of the columns of the given item.
+func (t *TableSelect) row(item interface{}) (*TableRow, error) {
+	row := &TableRow{Item: item}
+
+	for _, c := range t.Columns {
+		var value string
+
+		switch {
+		case c.tpl != nil:
+			var buf bytes.Buffer
+			err := c.tpl.Execute(&buf, item)
+			if err != nil {
+				return nil, err
+			}
+			value = buf.String()
+		case c.Field != "":
+			v, err := field(item, c.Field)
+			if err != nil {
+				return nil, err
+			}
+			value = fmt.Sprint(v)
+		default:
+			value = fmt.Sprint(item)
+		}
+
+		row.values = append(row.values, value)
+	}
+
+	return row, nil
+}
+
+// layout aligns the values of the rows inside the width of their column, computing the widths of the columns
+// that don't set any from the widest value across all rows. It returns the widths of the columns.
+func (t *TableSelect) layout(rows []*TableRow) []int {
+	widths := make([]int, len(t.Columns))
+	for i, c := range t.Columns {
+		widths[i] = c.Width
+		if widths[i] > 0 {
+			continue
+		}
+
+		for _, row := range rows {
+			if w := width(row.values[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+
+		// leave room for the sort marker of the header
+		if c.SortKey.Code != 0 && width(c.Header)+2 > widths[i] {
+			widths[i] = width(c.Header) + 2
+		}
+	}
+
+	for _, row := range rows {
+		row.Cells = make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			row.Cells[i] = align(c, row.values[i], widths[i])
+
+			// plain values are searched and sorted without their styling
+			row.values[i] = stripCodes(row.values[i])
+		}
+	}
+
+	return widths
+}
+
+// align aligns the value of the given column inside the given width, cutting the value when it is too wide.
+func align(c Column, value string, size int) string {
+	w := width(value)
+	if w > size {
+		value = cut(stripCodes(value), size)
+		w = width(value)
+	}
+
+	pad := size - w
+	switch c.Align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + value
+	case AlignCenter:
+		return strings.Repeat(" ", pad/2) + value + strings.Repeat(" ", pad-pad/2)
+	}
+	return value + strings.Repeat(" ", pad)
+}
+
+// searchedColumn returns the column named by a "header:term" search and the term itself. The NotFound column
+// is returned when the term doesn't name a column.
+func (t *TableSelect) searchedColumn(input string) (int, string) {
+	i := strings.Index(input, ":")
+	if i < 0 {
+		return list.NotFound, input
+	}
+
+	name := strings.TrimSpace(input[:i])
+	for j, c := range t.Columns {
+		if strings.EqualFold(c.Header, name) {
+			return j, strings.TrimSpace(input[i+1:])
+		}
+	}
+
+	return list.NotFound, input
+}
+
+func (t *TableSelect) prepareTemplates() error {
+	tpls := t.Templates
+	if tpls == nil {
+		tpls = &TableSelectTemplates{}
+	}
+
+	if tpls.Help == "" {
+		var keys []string
+		for _, c := range t.Columns {
+			if c.SortKey.Code != 0 {
+				keys = append(keys, c.SortKey.Display)
+			}
+		}
+
+		sorts := ""
+		if len(keys) > 0 {
+			sorts = fmt.Sprintf(`{{ if .Search }} {{ end }}{{ "%s" | faint }} {{ "sort the columns" | faint }}`,
+				strings.Join(keys, " "))
+		}
+
+		tpls.Help = `{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} ` +
+			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} ` +
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}` +
+			sorts
+	}
+
+	s := &Select{Templates: &tpls.SelectTemplates}
+
+	err := s.prepareTemplates()
+	if err != nil {
+		return err
+	}
+
+	if tpls.Header == "" {
+		tpls.Header = "  {{ . | bold }}"
+	}
+
+	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Header)
+	if err != nil {
+		return err
+	}
+
+	tpls.header = tpl
+
+	for i, c := range t.Columns {
+		if c.Template == "" {
+			continue
+		}
+
+		tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(c.Template)
+		if err != nil {
+			return fmt.Errorf("column %q: %v", c.Header, err)
+		}
+
+		t.Columns[i].tpl = tpl
+	}
+
+	t.Templates = tpls
+
+	return nil
+}
+
+// field returns the value of the struct field or of the map key of the given name.
+func field(item interface{}, name string) (interface{}, error) {
+	v := reflect.ValueOf(item)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		f := v.FieldByName(name)
+		if f.IsValid() && f.CanInterface() {
+			return f.Interface(), nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			f := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if f.IsValid() {
+				return f.Interface(), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%v has no field %q", item, name)
+}
+
+// lessValue compares two values of a column, as numbers when both are numbers.
+func lessValue(a, b string) bool {
+	x, errX := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errX == nil && errY == nil {
+		return x < y
+	}
+
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// width returns the number of columns the given text takes on the terminal, without its styling.
+func width(s string) int {
+	return readline.Runes{}.WidthAll([]rune(stripCodes(s)))
+}
+
+// cut shortens the given text to the given width, ending it with an ellipsis.
+func cut(s string, size int) string {
+	if size <= 0 {
+		return ""
+	}
+
+	var r []rune
+	w := 0
+	for _, c := range s {
+		cw := readline.Runes{}.Width(c)
+		if w+cw > size-1 {
+			break
+		}
+		r = append(r, c)
+		w += cw
+	}
+
+	return string(r) + "…"
+}
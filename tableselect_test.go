@@ -0,0 +1,141 @@
+package promptui
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type tableFile struct {
+	Name string
+	Size int
+	Kind string
+}
+
+func TestTableSelectRun(t *testing.T) {
+	items := []tableFile{
+		{Name: "notes.txt", Size: 120, Kind: "text"},
+		{Name: "photo.png", Size: 4096, Kind: "image"},
+		{Name: "a.md", Size: 8, Kind: "text"},
+	}
+
+	columns := []Column{
+		{Header: "Name", Field: "Name", SortKey: Key{Code: KeySort, Display: KeySortDisplay}},
+		{Header: "Size", Field: "Size", Align: AlignRight, SortKey: Key{Code: KeyTab, Display: "tab"}},
+		{Header: "Kind", Template: `{{ .Kind | cyan }}`},
+	}
+
+	searcher := func(input, value string) bool {
+		return strings.Contains(strings.ToLower(value), input)
+	}
+
+	tcs := []struct {
+		name  string
+		input string
+		index int
+	}{
+		{name: "selects the first item", input: "\r", index: 0},
+		{name: "sorts by a column", input: "\x0f\r", index: 0},
+		{name: "keeps the selected item when sorting", input: "\x0f\x0e\r", index: 1},
+		{name: "sorts numbers", input: "\t\x10\x10\r", index: 2},
+		{name: "sorts in descending order", input: "\t\t\x10\x10\r", index: 1},
+		{name: "sorts in the original order again", input: "\t\t\t\x0e\r", index: 1},
+		{name: "searches all columns", input: "/image\r", index: 1},
+		{name: "searches a column", input: "/kind:text\x0e\r", index: 2},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := TableSelect{
+				Label:    "File",
+				Items:    items,
+				Columns:  columns,
+				Searcher: searcher,
+				Stdin:    ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:   &closeBuffer{},
+			}
+
+			idx, item, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running table select %v", err)
+			}
+
+			if idx != tc.index || item != items[tc.index] {
+				t.Errorf("Expected %v at %d, got %v at %d", items[tc.index], tc.index, item, idx)
+			}
+		})
+	}
+
+	t.Run("types the sort keys into the search", func(t *testing.T) {
+		sortable := []Column{{Header: "Name", Field: "Name", SortKey: Key{Code: 's', Display: "s"}}}
+
+		for input, index := range map[string]int{"s\x10\r": 2, "/s\x10\r": 0} {
+			s := TableSelect{
+				Label:    "File",
+				Items:    items,
+				Columns:  sortable,
+				Searcher: searcher,
+				Stdin:    ioutil.NopCloser(strings.NewReader(input)),
+				Stdout:   &closeBuffer{},
+			}
+
+			idx, _, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running table select %v", err)
+			}
+
+			if idx != index {
+				t.Errorf("Expected %d to be selected after %q, got %d", index, input, idx)
+			}
+		}
+	})
+
+	t.Run("aligns the columns", func(t *testing.T) {
+		out := &closeBuffer{}
+		s := TableSelect{
+			Label:   "File",
+			Items:   items,
+			Columns: columns,
+			Size:    2,
+			Stdin:   ioutil.NopCloser(strings.NewReader("\x0f\r")),
+			Stdout:  out,
+		}
+
+		_, _, err := s.Run()
+		if err != nil {
+			t.Fatalf("Unexpected error running table select %v", err)
+		}
+
+		lines := strings.Split(stripCodes(out.String()), "\n")
+		expected := []string{
+			"    Name ↑       Size  Kind",
+			"↑ ▸ notes.txt     120  text",
+			"    photo.png    4096  image",
+		}
+		for _, line := range expected {
+			if !containsLine(lines, line) {
+				t.Errorf("Expected line %q inside the output %q", line, lines)
+			}
+		}
+	})
+
+	t.Run("cuts the columns wider than their width", func(t *testing.T) {
+		s := TableSelect{Columns: []Column{{Header: "Name", Width: 6}}}
+
+		rows := []*TableRow{{values: []string{"notes.txt"}}, {values: []string{"a"}}}
+		s.layout(rows)
+
+		if rows[0].String() != "notes…" || rows[1].String() != "a     " {
+			t.Errorf("Expected cut and padded cells, got %q and %q", rows[0], rows[1])
+		}
+	})
+
+	t.Run("when an item has no such field", func(t *testing.T) {
+		s := TableSelect{Items: items, Columns: []Column{{Header: "Owner", Field: "Owner"}}}
+
+		_, _, err := s.Run()
+		if err == nil {
+			t.Errorf("Expected an error for the missing field")
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package promptui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lemotw/promptui/list"
+	"github.com/lemotw/promptui/multidimlist"
+)
+
+// TreeNode is the value given to the templates of a TreeSelect for each row of the tree.
+type TreeNode struct {
+	*multidimlist.Row
+}
+
+// Indent returns the indentation of the node matching its depth inside the tree.
+func (n *TreeNode) Indent() string {
+	return strings.Repeat("  ", n.Depth())
+}
+
+// String returns the label of the item of the node. The nested items of a slice are joined together.
+func (n *TreeNode) String() string {
+	if isSlice(n.Item) {
+		return joinSlice(" & ", n.Item)
+	}
+	return fmt.Sprint(multidimlist.Label(n.Item))
+}
+
+// TreeSelect is a select list displaying nested items as an indented tree. Branches can be expanded and
+// collapsed in place to display or hide their nested items. It navigates the same items as MultidimSelect.
+type TreeSelect struct {
+	// Label is the text displayed on top of the list to direct input. The IconInitial value "?" will be
+	// appended automatically to the label so it does not need to be added.
+	Label interface{}
+	// Items are the items to display inside the tree. Branches holding nested items are either slices, maps
+	// of labels to their nested items or values implementing multidimlist.Node.
+	Items interface{}
+
+	// Templates can be used to customize the tree select output. If nil is passed, the default templates
+	// are used. The templates receive a TreeNode for each row of the tree.
+	Templates *SelectTemplates
+	// Keys is the set of keys used to control the interface. See the TreeSelectKeys docs for more info.
+	Keys *TreeSelectKeys
+	// Input/Output streams
+	Stdin  io.ReadCloser
+	Stdout io.WriteCloser
+	// A function that determines how to render the cursor
+	Pointer Pointer
+	// Searcher is a function that can be implemented to search the items of the tree. Search displays the
+	// matching items along with their ancestors. It is unimplemented by default and search will not work
+	// unless it is implemented.
+	Searcher multidimlist.Searcher
+
+	// Size is the number of rows that should appear before scrolling is necessary. Defaults to 5.
+	Size int
+
+	// StartExpanded sets whether all branches start expanded.
+	StartExpanded bool
+	// IsVimMode sets whether to use vim mode when using readline in the command prompt.
+	IsVimMode bool
+	// HideHelp sets whether to hide help information.
+	HideHelp bool
+	// HideSelected sets whether to hide the text displayed after an item is successfully selected.
+	HideSelected bool
+	// StartInSearchMode sets whether or not the select should start in search mode.
+	StartInSearchMode bool
+}
+
+// TreeSelectKeys defines the available keys used by a tree select. It extends the SelectKeys with the keys
+// used to expand and collapse the branches, which take precedence over the page keys.
+type TreeSelectKeys struct {
+	SelectKeys
+
+	// Expand is the key used to expand the active branch, or to move to its first nested item when it is
+	// already expanded. Defaults to the right arrow key.
+	Expand Key
+
+	// Collapse is the key used to collapse the active branch, or to move to the parent of the active item
+	// when there is nothing to collapse. Defaults to the left arrow key.
+	Collapse Key
+}
+
+// Run executes the tree select. It displays the label and the tree of items, asking the user to chose any
+// item. It returns the indices of the item across dimensions, like MultidimSelect, the item and an error if
+// any occurred during the select's execution.
+func (t *TreeSelect) Run() ([]int, interface{}, error) {
+	tree, err := multidimlist.NewTree(t.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree.Searcher = t.Searcher
+
+	if t.StartExpanded {
+		tree.ExpandAll()
+	}
+
+	if t.Size == 0 {
+		t.Size = 5
+	}
+
+	t.setKeys()
+
+	err = t.prepareTemplates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &Select{
+		Label:             t.Label,
+		Templates:         t.Templates,
+		Keys:              &t.Keys.SelectKeys,
+		Stdin:             t.Stdin,
+		Stdout:            t.Stdout,
+		Pointer:           t.Pointer,
+		Size:              t.Size,
+		IsVimMode:         t.IsVimMode,
+		HideHelp:          t.HideHelp,
+		HideSelected:      t.HideSelected,
+		StartInSearchMode: t.StartInSearchMode,
+	}
+
+	if t.Searcher != nil {
+		// the tree is searched by onSearch, this only enables the search mode
+		s.Searcher = func(input string, index int) bool { return true }
+	}
+
+	var nodes []*TreeNode
+	term := ""
+
+	// build recreates the list from the rows of the tree, keeping the cursor on the node at the given path
+	// or on its closest displayed ancestor
+	build := func(path []int) error {
+		var rows []*multidimlist.Row
+		if term == "" {
+			rows = tree.Rows()
+		} else {
+			rows = tree.Search(term)
+		}
+
+		nodes = make([]*TreeNode, len(rows))
+		for i, row := range rows {
+			nodes[i] = &TreeNode{Row: row}
+		}
+
+		l, err := list.New(nodes, t.Size)
+		if err != nil {
+			return err
+		}
+		l.Labeler = func(item interface{}) string {
+			return item.(*TreeNode).String()
+		}
+
+		s.list = l
+
+		cursor, depth := 0, 0
+		for i, node := range nodes {
+			if len(node.Path) > depth && isAncestor(node.Path, path) {
+				cursor, depth = i, len(node.Path)
+			}
+		}
+		s.list.SetCursor(cursor)
+
+		return nil
+	}
+
+	active := func() *TreeNode {
+		items, idx := s.list.Items()
+		if idx == list.NotFound {
+			return nil
+		}
+		return items[idx].(*TreeNode)
+	}
+
+	s.onKey = func(key rune, searched string, searching bool) bool {
+		node := active()
+		if node == nil || searched != "" {
+			return false
+		}
+
+		switch key {
+		case t.Keys.Expand.Code:
+			if node.Branch && !node.Expanded {
+				tree.Expand(node.Path)
+				build(node.Path)
+			} else if node.Branch {
+				s.list.Next()
+			}
+		case t.Keys.Collapse.Code:
+			if node.Expanded {
+				tree.Collapse(node.Path)
+				build(node.Path)
+			} else if node.Depth() > 0 {
+				build(node.Path[:len(node.Path)-1])
+			}
+		default:
+			return false
+		}
+
+		return true
+	}
+
+	s.onSearch = func(searched string) {
+		var path []int
+		if node := active(); node != nil && searched == "" {
+			path = node.Path
+
+			// keep the active node displayed once the search ends
+			for i := 1; i < len(path); i++ {
+				tree.Expand(path[:i])
+			}
+		}
+
+		term = searched
+		build(path)
+	}
+
+	err = build(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, item, err := s.innerRun(0, 0, ' ')
+	if err != nil {
+		return nil, nil, err
+	}
+
+	node := item.(*TreeNode)
+	return node.Path, node.Item, nil
+}
+
+func (t *TreeSelect) setKeys() {
+	if t.Keys != nil {
+		return
+	}
+
+	s := &Select{}
+	s.setKeys()
+
+	t.Keys = &TreeSelectKeys{
+		SelectKeys: *s.Keys,
+		Expand:     Key{Code: KeyForward, Display: KeyForwardDisplay},
+		Collapse:   Key{Code: KeyBackward, Display: KeyBackwardDisplay},
+	}
+}
+
+func (t *TreeSelect) prepareTemplates() error {
+	tpls := t.Templates
+	if tpls == nil {
+		tpls = &SelectTemplates{}
+	}
+
+	marker := `{{ if not .Branch }} {{ else if .Expanded }}{{ "-" | faint }}{{ else }}{{ "+" | faint }}{{ end }}`
+
+	if tpls.Active == "" {
+		tpls.Active = fmt.Sprintf("%s {{ .Indent }}%s {{ . | underline }}", IconSelect, marker)
+	}
+
+	if tpls.Inactive == "" {
+		tpls.Inactive = fmt.Sprintf("  {{ .Indent }}%s {{ . }}", marker)
+	}
+
+	if tpls.Help == "" {
+		tpls.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} `+
+			`{{ .PrevKey | faint }} {{ "%s" | faint }} {{ "%s" | faint }} {{ "expand and collapse" | faint }}`+
+			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}`,
+			t.Keys.Expand.Display, t.Keys.Collapse.Display)
+	}
+
+	s := &Select{Templates: tpls}
+
+	err := s.prepareTemplates()
+	if err != nil {
+		return err
+	}
+
+	t.Templates = s.Templates
+
+	return nil
+}
+
+// isAncestor returns whether the node at the given path is the node at the other path or one of its ancestors.
+func isAncestor(path, other []int) bool {
+	if len(path) > len(other) {
+		return false
+	}
+
+	for i, index := range path {
+		if other[i] != index {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package promptui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lemotw/promptui/multidimlist"
+)
+
+func TestTreeSelectRun(t *testing.T) {
+	items := []interface{}{
+		"apple",
+		[]interface{}{"beet", "bean"},
+		[]interface{}{"cherry", []interface{}{"corn", "cress"}},
+	}
+
+	searcher := func(input string, item interface{}, index int) bool {
+		s, ok := item.(string)
+		return ok && strings.Contains(s, input)
+	}
+
+	tcs := []struct {
+		name     string
+		input    string
+		expanded bool
+		path     []int
+		item     interface{}
+	}{
+		{name: "selects the first item", input: "\r", path: []int{0}, item: "apple"},
+		{name: "expands a branch", input: "j\x06j\r", path: []int{1, 0}, item: "beet"},
+		{name: "moves into an expanded branch", input: "j\x06\x06j\r", path: []int{1, 1}, item: "bean"},
+		{name: "collapses to the parent", input: "j\x06\x06\x02\r", path: []int{1}, item: []interface{}{"beet", "bean"}},
+		{name: "collapses a branch", input: "j\x02j\r", expanded: true, path: []int{2}, item: items[2]},
+		{name: "starts expanded", input: "jj\r", expanded: true, path: []int{1, 0}, item: "beet"},
+		{name: "searches nested items", input: "/cr\x0e\x0e\r", path: []int{2, 1, 1}, item: "cress"},
+		{name: "keeps the item found once the search ends", input: "/corn\x0e\x0e/j\r", path: []int{2, 1, 1}, item: "cress"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := TreeSelect{
+				Label:         "Food",
+				Items:         items,
+				Searcher:      searcher,
+				StartExpanded: tc.expanded,
+				Stdin:         ioutil.NopCloser(strings.NewReader(tc.input)),
+				Stdout:        &closeBuffer{},
+			}
+
+			path, item, err := s.Run()
+			if err != nil {
+				t.Fatalf("Unexpected error running tree select %v", err)
+			}
+
+			if !reflect.DeepEqual(path, tc.path) || !reflect.DeepEqual(item, tc.item) {
+				t.Errorf("Expected %v %v, got %v %v", tc.path, tc.item, path, item)
+			}
+		})
+	}
+
+	t.Run("when items are not a slice", func(t *testing.T) {
+		s := TreeSelect{Items: "apple"}
+
+		_, _, err := s.Run()
+		if err == nil {
+			t.Errorf("Expected an error for items %v", s.Items)
+		}
+	})
+}
+
+func TestTreeSelectTemplateRender(t *testing.T) {
+	s := TreeSelect{Label: "Food"}
+	s.setKeys()
+
+	err := s.prepareTemplates()
+	if err != nil {
+		t.Fatalf("Unexpected error preparing templates %v", err)
+	}
+
+	tcs := []struct {
+		node     *TreeNode
+		active   bool
+		expected string
+	}{
+		{node: node("apple", false, false, 0), expected: "    apple"},
+		{node: node("beet", false, false, 1, 0), expected: "      beet"},
+		{node: node([]string{"beet", "bean"}, true, false, 1), expected: "  \x1b[2m+\x1b[0m beet & bean"},
+		{node: node([]string{"beet", "bean"}, true, true, 1), expected: "  \x1b[2m-\x1b[0m beet & bean"},
+		{node: node("beet", false, false, 1, 0), active: true, expected: "\x1b[1m▸\x1b[0m     \x1b[4mbeet\x1b[0m"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprint(tc.node), func(t *testing.T) {
+			tpl := s.Templates.inactive
+			if tc.active {
+				tpl = s.Templates.active
+			}
+
+			result := string(render(tpl, tc.node))
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func node(item interface{}, branch, expanded bool, path ...int) *TreeNode {
+	return &TreeNode{Row: &multidimlist.Row{Item: item, Path: path, Branch: branch, Expanded: expanded}}
+}